@@ -2,6 +2,7 @@ package analyzer
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/migalabs/goteth/pkg/events"
 	prom_metrics "github.com/migalabs/goteth/pkg/metrics"
 	"github.com/migalabs/goteth/pkg/relay"
+	"github.com/migalabs/goteth/pkg/sink"
 	"github.com/migalabs/goteth/pkg/spec"
 	"github.com/migalabs/goteth/pkg/utils"
 	"github.com/newrelic/go-agent/v3/newrelic"
@@ -33,7 +35,7 @@ type ChainAnalyzer struct {
 	cli       *clientapi.APIClient // client to request data to the CL and EL clients
 	relayCli  *relay.RelaysMonitor // client to monitor all relays in list
 	eventsObj events.Events        // object to receive signals from beacon node
-	dbClient  *db.DBService        // client to communicate with clickhouse
+	dbClient  db.Store             // storage where the metrics are persisted
 
 	nr *newrelic.Application
 
@@ -43,6 +45,7 @@ type ChainAnalyzer struct {
 	stop          bool               // flag to notify all routine to finish
 	routineClosed chan struct{}      // signal that everything was closed succesfully
 	downloadMode  string             // whether to download historical blocks (defined by user) or follow chain head
+	workerNum     int                // workers to process validators in parallel
 	metrics       db.DBMetrics       // waht metrics to be downloaded / processed
 	processerBook *utils.RoutineBook // defines slot to process new metrics into the database, good for monitoring
 
@@ -85,7 +88,23 @@ func NewChainAnalyzer(
 		}, errors.Wrap(err, "unable to read metric")
 	}
 
-	idbClient, err := db.New(ctx, iConfig.DBUrl)
+	dbOptions := []db.DBServiceOption{
+		db.WithDBWorkers(iConfig.DbWorkerNum),
+		db.WithSpillDir(iConfig.SpillDir),
+	}
+	var eventSink *sink.KafkaSink
+	if iConfig.SinkBrokers != "" {
+		eventSink, err = sink.NewKafkaSink(ctx, strings.Split(iConfig.SinkBrokers, ","), iConfig.SinkPrefix, iConfig.SinkFormat)
+		if err != nil {
+			return &ChainAnalyzer{
+				ctx:    ctx,
+				cancel: cancel,
+			}, errors.Wrap(err, "unable to generate event sink")
+		}
+		dbOptions = append(dbOptions, db.WithSink(eventSink, strings.Split(iConfig.SinkTables, ",")...))
+	}
+
+	idbClient, err := db.New(ctx, iConfig.DBUrl, dbOptions...)
 	if err != nil {
 		return &ChainAnalyzer{
 			ctx:    ctx,
@@ -141,6 +160,7 @@ func NewChainAnalyzer(
 		routineClosed:    make(chan struct{}, 1),
 		eventsObj:        events.NewEventsObj(ctx, cli),
 		downloadMode:     iConfig.DownloadMode,
+		workerNum:        iConfig.WorkerNum,
 		metrics:          metricsObj,
 		PromMetrics:      promethMetrics,
 		downloadCache:    NewQueue(),
@@ -149,10 +169,18 @@ func NewChainAnalyzer(
 		wgDownload:       &sync.WaitGroup{},
 	}
 
+	if analyzer.workerNum < 1 || analyzer.workerNum > maxWorkers {
+		log.Warnf("invalid number of workers %d, using %d", analyzer.workerNum, config.DefaultWorkerNum)
+		analyzer.workerNum = config.DefaultWorkerNum
+	}
+
 	analyzerMet := analyzer.GetPrometheusMetrics()
 	promethMetrics.AddMeticsModule(analyzerMet)
 	promethMetrics.AddMeticsModule(analyzer.processerBook.GetPrometheusMetrics())
 	promethMetrics.AddMeticsModule(idbClient.GetPrometheusMetrics())
+	if eventSink != nil {
+		promethMetrics.AddMeticsModule(eventSink.GetPrometheusMetrics())
+	}
 
 	nr, errNr := newrelic.NewApplication(
 		newrelic.ConfigAppName("goteth"),
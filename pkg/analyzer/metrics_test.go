@@ -3,6 +3,7 @@ package analyzer
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 	"testing"
 
@@ -290,6 +291,110 @@ func TestAltairNegativeRewards(t *testing.T) {
 		phase0.Gwei(36855786132))
 }
 
+func TestAltairRewardComponents(t *testing.T) {
+
+	analyzer, err := BuildChainAnalyzer()
+	if err != nil {
+		t.Errorf("could not build analyzer: %s", err)
+		return
+	}
+
+	// returns the state in a custom struct for Phase0, Altair of Bellatrix
+	stateMetrics, err := BuildEpochTask(&analyzer, 6565759) // epoch 205179
+	if err != nil {
+		t.Errorf("could not build epoch task: %s", err)
+		return
+	}
+
+	// Test when validator performed all duties
+	rewards, err := stateMetrics.GetMaxReward(1250)
+	assert.NoError(t, err)
+	assert.Equal(t, rewards.Reward, rewards.Total())
+	assert.Greater(t, rewards.SourceReward, int64(0))
+	assert.Greater(t, rewards.TargetReward, int64(0))
+	assert.Greater(t, rewards.HeadReward, int64(0))
+	assert.Equal(t, rewards.SyncReward, int64(0))
+	assert.Equal(t, rewards.InactivityReward, int64(0))
+
+	// Test when validator was in a sync committee
+	rewards, err = stateMetrics.GetMaxReward(325479)
+	assert.NoError(t, err)
+	assert.Equal(t, rewards.Reward, rewards.Total())
+	assert.Greater(t, rewards.SyncReward, int64(0))
+
+	// Test negative rewards
+	stateMetrics, err = BuildEpochTask(&analyzer, 6565823) // epoch 205181
+	if err != nil {
+		t.Errorf("could not build epoch task: %s", err)
+		return
+	}
+	rewards, err = stateMetrics.GetMaxReward(9097)
+	assert.NoError(t, err)
+	assert.Equal(t, rewards.Reward, rewards.Total())
+	assert.Less(t, rewards.SourceReward, int64(0))
+	assert.Less(t, rewards.TargetReward, int64(0))
+	assert.Equal(t, rewards.HeadReward, int64(0))
+}
+
+func TestAltairInclusionDelays(t *testing.T) {
+
+	analyzer, err := BuildChainAnalyzer()
+	if err != nil {
+		t.Errorf("could not build analyzer: %s", err)
+		return
+	}
+
+	// returns the state in a custom struct for Phase0, Altair of Bellatrix
+	stateMetrics, err := BuildEpochTask(&analyzer, 6565759) // epoch 205179
+	if err != nil {
+		t.Errorf("could not build epoch task: %s", err)
+		return
+	}
+
+	assertInclusionDelays(t, stateMetrics.GetMetricsBase(), spec.SlotsPerEpoch+1)
+}
+
+func TestDenebInclusionDelays(t *testing.T) {
+
+	analyzer, err := BuildChainAnalyzer()
+	if err != nil {
+		t.Errorf("could not build analyzer: %s", err)
+		return
+	}
+
+	// returns the state in a custom struct for Phase0, Altair of Bellatrix
+	stateMetrics, err := BuildEpochTask(&analyzer, 8640031) // epoch 270000
+	if err != nil {
+		t.Errorf("could not build epoch task: %s", err)
+		return
+	}
+
+	assertInclusionDelays(t, stateMetrics.GetMetricsBase(), 2*spec.SlotsPerEpoch)
+}
+
+// every validator must have an inclusion delay, and it must match the flags
+// the chain granted to its attestation in the previous epoch participation
+func assertInclusionDelays(t *testing.T, base metrics.StateMetricsBase, maxDelay int) {
+	flags := base.CurrentState.PrevEpochCorrectFlags
+
+	assert.Equal(t, len(base.InclusionDelays), len(base.NextState.Validators))
+
+	for valIdx, inclusionDelay := range base.InclusionDelays {
+		assert.GreaterOrEqual(t, inclusionDelay, 1)
+		assert.LessOrEqual(t, inclusionDelay, maxDelay)
+
+		if valIdx >= len(flags[spec.AttHeadFlagIndex]) {
+			continue
+		}
+		if flags[spec.AttHeadFlagIndex][valIdx] {
+			assert.Equal(t, 1, inclusionDelay, "validator %d got the head flag", valIdx)
+		}
+		if flags[spec.AttSourceFlagIndex][valIdx] {
+			assert.LessOrEqual(t, inclusionDelay, int(math.Sqrt(spec.SlotsPerEpoch)), "validator %d got the source flag", valIdx)
+		}
+	}
+}
+
 func TestCapellaBlock(t *testing.T) {
 
 	blockAnalyzer, err := BuildChainAnalyzerWithEL()
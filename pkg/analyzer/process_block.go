@@ -48,6 +48,40 @@ func (s *ChainAnalyzer) ProcessBlock(slot phase0.Slot) {
 		log.Errorf("error persisting withdrawals: %s", err.Error())
 	}
 
+	var exits []spec.VoluntaryExit
+	for _, item := range block.VoluntaryExits {
+		exits = append(exits, spec.VoluntaryExit{
+			ValIdx: item.Message.ValidatorIndex,
+			Epoch:  item.Message.Epoch,
+			Slot:   block.Slot,
+		})
+	}
+
+	if len(exits) > 0 {
+		err = s.dbClient.PersistVoluntaryExits(exits)
+		if err != nil {
+			log.Errorf("error persisting voluntary exits: %s", err.Error())
+		}
+	}
+
+	var blsChanges []spec.BLSToExecutionChange
+	for _, item := range block.BLSToExecutionChanges {
+		blsChanges = append(blsChanges, spec.BLSToExecutionChange{
+			Slot:               block.Slot,
+			Epoch:              phase0.Epoch(block.Slot / spec.SlotsPerEpoch),
+			ValidatorIndex:     item.Message.ValidatorIndex,
+			FromBLSPublicKey:   item.Message.FromBLSPubkey,
+			ToExecutionAddress: item.Message.ToExecutionAddress,
+		})
+	}
+
+	if len(blsChanges) > 0 {
+		err = s.dbClient.PersistBLSToExecutionChanges(blsChanges)
+		if err != nil {
+			log.Errorf("error persisting bls to execution changes: %s", err.Error())
+		}
+	}
+
 	if s.metrics.Transactions {
 		s.processTransactions(block)
 		s.processBlobSidecars(block, block.ExecutionPayload.AgnosticTransactions)
@@ -0,0 +1,145 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/clientapi"
+	"github.com/migalabs/goteth/pkg/db"
+	"github.com/migalabs/goteth/pkg/spec"
+	"github.com/migalabs/goteth/pkg/utils"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildMemoryChainAnalyzer(t *testing.T) (*ChainAnalyzer, *db.MemoryStore) {
+	store, err := db.NewMemoryStore(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(store.Finish)
+
+	return &ChainAnalyzer{
+		ctx:           context.Background(),
+		dbClient:      store,
+		metrics:       db.DBMetrics{Block: true},
+		processerBook: utils.NewRoutineBook(32, "processer"),
+		downloadCache: NewQueue(),
+	}, store
+}
+
+// Beacon node that is synced but does not have any block, state or duty
+func fakeBeaconNode(t *testing.T) *clientapi.APIClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/eth/v1/node/syncing":
+			fmt.Fprint(w, `{"data":{"head_slot":"101","sync_distance":"0","is_syncing":false,"is_optimistic":false,"el_offline":false}}`)
+		case "/eth/v1/node/version":
+			fmt.Fprint(w, `{"data":{"version":"fake"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":404,"message":"NOT_FOUND"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cli, err := clientapi.NewAPIClient(ctx, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
+
+func syntheticBlock(slot phase0.Slot, proposer phase0.ValidatorIndex, attestations int) *spec.AgnosticBlock {
+	block := &spec.AgnosticBlock{
+		Slot:          slot,
+		ProposerIndex: proposer,
+		Proposed:      true,
+		Root:          phase0.Root{byte(proposer)},
+		SyncAggregate: &altair.SyncAggregate{SyncCommitteeBits: bitfield.NewBitvector512()},
+		ExecutionPayload: spec.AgnosticExecutionPayload{
+			Withdrawals: []*capella.Withdrawal{{Index: 7, ValidatorIndex: 3, Amount: 1000}},
+		},
+	}
+	for i := 0; i < attestations; i++ {
+		block.Attestations = append(block.Attestations, &phase0.Attestation{
+			Data: &phase0.AttestationData{
+				Slot:   slot - 1,
+				Index:  phase0.CommitteeIndex(i),
+				Source: &phase0.Checkpoint{},
+				Target: &phase0.Checkpoint{Epoch: phase0.Epoch(slot / spec.SlotsPerEpoch)},
+			},
+		})
+	}
+	return block
+}
+
+func TestProcessBlock(t *testing.T) {
+	s, store := buildMemoryChainAnalyzer(t)
+
+	s.downloadCache.AddNewBlock(syntheticBlock(100, 42, 2))
+	s.ProcessBlock(100)
+
+	blocks := store.Rows("t_block_metrics")
+	if assert.Len(t, blocks, 1) {
+		assert.Equal(t, uint64(100), blocks[0]["f_slot"])
+		assert.Equal(t, uint64(100/spec.SlotsPerEpoch), blocks[0]["f_epoch"])
+		assert.Equal(t, uint64(42), blocks[0]["f_proposer_index"])
+		assert.Equal(t, uint64(2), blocks[0]["f_attestations"])
+	}
+
+	attestations := store.Rows("t_attestations")
+	if assert.Len(t, attestations, 2) {
+		assert.Equal(t, uint64(99), attestations[0]["f_attestation_slot"])
+		assert.Equal(t, uint64(1), attestations[1]["f_attestation_index"])
+	}
+
+	withdrawals := store.Rows("t_withdrawals")
+	if assert.Len(t, withdrawals, 1) {
+		assert.Equal(t, uint64(3), withdrawals[0]["f_val_idx"])
+		assert.Equal(t, uint64(1000), withdrawals[0]["f_amount"])
+	}
+}
+
+// The block at the head is reorged out: the node no longer has it, so HandleReorg keeps it
+// as an orphan and rewrites the slot as missed
+func TestProcessBlockRewrite(t *testing.T) {
+	s, store := buildMemoryChainAnalyzer(t)
+	s.cli = fakeBeaconNode(t)
+
+	s.downloadCache.AddNewBlock(syntheticBlock(99, 41, 1))
+	s.ProcessBlock(99)
+	s.downloadCache.AddNewBlock(syntheticBlock(100, 42, 2))
+	s.ProcessBlock(100)
+
+	// slot 101 builds on top of 99, only the head block is reorged
+	s.HandleReorg(v1.ChainReorgEvent{Slot: 101, Depth: 0})
+
+	blocks := store.Rows("t_block_metrics")
+	if assert.Len(t, blocks, 2) {
+		assert.Equal(t, uint64(41), blocks[0]["f_proposer_index"])
+		assert.Equal(t, uint64(100), blocks[1]["f_slot"])
+		assert.Equal(t, false, blocks[1]["f_proposed"])
+	}
+	orphans := store.Rows("t_orphans")
+	if assert.Len(t, orphans, 1) {
+		assert.Equal(t, uint64(100), orphans[0]["f_slot"])
+		assert.Equal(t, uint64(42), orphans[0]["f_proposer_index"])
+	}
+	assert.Len(t, store.Rows("t_attestations"), 1)
+	assert.Len(t, store.Rows("t_withdrawals"), 1)
+}
@@ -2,12 +2,14 @@ package analyzer
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/migalabs/goteth/pkg/db"
 	"github.com/migalabs/goteth/pkg/relay"
 	"github.com/migalabs/goteth/pkg/spec"
 	"github.com/migalabs/goteth/pkg/spec/metrics"
+	"github.com/migalabs/goteth/pkg/utils"
 )
 
 var (
@@ -50,12 +52,24 @@ func (s *ChainAnalyzer) ProcessStateTransitionMetrics(epoch phase0.Epoch) {
 	// If nextState is filled, we can process proposer duties
 	if !nextState.EmptyStateRoot() {
 		s.processEpochDuties(bundle)
+		s.processEpochFinality(bundle)
+		s.processSlashings(bundle)
 		s.processValLastStatus(bundle)
+		s.processValStatusHistory(bundle)
+		if s.metrics.SyncCommittee {
+			s.processSyncCommitteeMembers(bundle)
+			s.processSyncCommitteeParticipation(bundle)
+		}
 
 		// If currentState and nextState are filled, we can process epoch metrics
 		if !currentState.EmptyStateRoot() {
 			s.processPoolMetrics(bundle.GetMetricsBase().CurrentState.Epoch)
 			s.processEpochMetrics(bundle)
+			s.processValidatorEvents(bundle)
+			s.processDeposits(bundle)
+			if s.metrics.Attestations {
+				s.processValidatorAttestations(bundle)
+			}
 
 			// If prevState, currentState and nextState are filled, we can process validator rewards
 			if !prevState.EmptyStateRoot() {
@@ -63,10 +77,16 @@ func (s *ChainAnalyzer) ProcessStateTransitionMetrics(epoch phase0.Epoch) {
 				if s.metrics.ValidatorRewards {
 					s.processEpochValRewards(bundle)
 				}
+				if s.metrics.RewardsAudit {
+					s.processRewardsAudit(bundle)
+				}
 			}
 		}
 	}
 
+	// epoch boundary: write what was buffered
+	s.dbClient.EndEpoch()
+
 	s.processerBook.FreePage(routineKey)
 
 }
@@ -86,6 +106,108 @@ func (s *ChainAnalyzer) processEpochMetrics(bundle metrics.StateMetrics) {
 
 }
 
+func (s *ChainAnalyzer) processValidatorEvents(bundle metrics.StateMetrics) {
+
+	// we need sameEpoch and nextEpoch validators
+
+	events := bundle.GetMetricsBase().ExportToValidatorEvents()
+
+	log.Debugf("persisting validator events: epoch %d", bundle.GetMetricsBase().NextState.Epoch)
+
+	if len(events) > 0 {
+		err := s.dbClient.PersistValidatorEvents(events)
+		if err != nil {
+			log.Errorf("error persisting validator events: %s", err.Error())
+		}
+	}
+}
+
+func (s *ChainAnalyzer) processDeposits(bundle metrics.StateMetrics) {
+
+	// we need currentState validators to tell new validators from top ups
+	deposits := bundle.GetMetricsBase().ExportToDeposits()
+	if len(deposits) == 0 {
+		return
+	}
+
+	// join the deposit contract logs when the execution layer is available
+	eth1Data := bundle.GetMetricsBase().NextState.Eth1Data
+	if s.cli.ELApi != nil && eth1Data != nil {
+		events, err := s.cli.RequestDepositEvents(eth1Data.BlockHash, deposits[0].Index, deposits[len(deposits)-1].Index)
+		if err != nil {
+			log.Errorf("error requesting deposit events: %s", err.Error())
+		}
+		for i := range deposits {
+			if event, ok := events[deposits[i].Index]; ok {
+				deposits[i].ELBlockNumber = event.BlockNumber
+				deposits[i].ELTxHash = event.TxHash
+				deposits[i].ELSender = event.Sender
+			}
+		}
+	}
+
+	log.Debugf("persisting deposits: epoch %d", bundle.GetMetricsBase().NextState.Epoch)
+
+	err := s.dbClient.PersistDeposits(deposits)
+	if err != nil {
+		log.Errorf("error persisting deposits: %s", err.Error())
+	}
+}
+
+func (s *ChainAnalyzer) processValidatorAttestations(bundle metrics.StateMetrics) {
+
+	// we need sameEpoch and nextEpoch blocks and sameEpoch committees
+
+	attestations := bundle.GetMetricsBase().ExportToValidatorAttestations()
+
+	log.Debugf("persisting validator attestations: epoch %d", bundle.GetMetricsBase().CurrentState.Epoch)
+
+	if len(attestations) > 0 {
+		err := s.dbClient.PersistValidatorAttestations(attestations)
+		if err != nil {
+			log.Errorf("error persisting validator attestations: %s", err.Error())
+		}
+	}
+}
+
+func (s *ChainAnalyzer) processSyncCommitteeMembers(bundle metrics.StateMetrics) {
+
+	epoch := bundle.GetMetricsBase().NextState.Epoch
+
+	// the committee only changes once per period, but also persist it for the first epoch we process
+	firstEpoch := epoch == phase0.Epoch(s.initSlot/spec.SlotsPerEpoch)
+	if epoch%spec.EpochsPerSyncCommitteePeriod != 0 && !firstEpoch {
+		return
+	}
+
+	members := bundle.GetMetricsBase().ExportToSyncCommitteeMembers()
+
+	log.Debugf("persisting sync committee members: period %d", spec.GetSyncCommitteePeriod(epoch))
+
+	if len(members) > 0 {
+		err := s.dbClient.PersistSyncCommitteeMembers(members)
+		if err != nil {
+			log.Errorf("error persisting sync committee members: %s", err.Error())
+		}
+	}
+}
+
+func (s *ChainAnalyzer) processSyncCommitteeParticipation(bundle metrics.StateMetrics) {
+
+	// we need nextState blocks and sync committee
+
+	participations := bundle.GetMetricsBase().ExportToSyncCommitteeParticipation()
+
+	log.Debugf("persisting sync committee participation: epoch %d", bundle.GetMetricsBase().NextState.Epoch)
+
+	if len(participations) > 0 {
+		err := s.dbClient.PersistSyncCommitteeParticipation(participations)
+		if err != nil {
+			log.Errorf("error persisting sync committee participation: %s", err.Error())
+		}
+	}
+}
+
 func (s *ChainAnalyzer) processPoolMetrics(epoch phase0.Epoch) {
 
 	log.Debugf("persisting pool summaries: epoch %d", epoch)
@@ -129,6 +251,34 @@ func (s *ChainAnalyzer) processEpochDuties(bundle metrics.StateMetrics) {
 
 }
 
+func (s *ChainAnalyzer) processSlashings(bundle metrics.StateMetrics) {
+
+	// we need nextState blocks and validators
+	slashings := bundle.GetMetricsBase().ExportToSlashings()
+
+	if len(slashings) > 0 {
+		log.Debugf("persisting slashings: epoch %d", bundle.GetMetricsBase().NextState.Epoch)
+
+		err := s.dbClient.PersistSlashings(slashings)
+		if err != nil {
+			log.Errorf("error persisting slashings: %s", err.Error())
+		}
+	}
+}
+
+func (s *ChainAnalyzer) processEpochFinality(bundle metrics.StateMetrics) {
+
+	// every state carries its own justification and finalization data
+	finality := bundle.GetMetricsBase().NextState.ExportToEpochFinality()
+
+	log.Debugf("persisting epoch finality: epoch %d", finality.Epoch)
+
+	err := s.dbClient.PersistEpochFinality([]spec.EpochFinality{finality})
+	if err != nil {
+		log.Errorf("error persisting epoch finality: %s", err.Error())
+	}
+}
+
 func (s *ChainAnalyzer) processValLastStatus(bundle metrics.StateMetrics) {
 
 	if s.downloadMode == "finalized" {
@@ -136,15 +286,16 @@ func (s *ChainAnalyzer) processValLastStatus(bundle metrics.StateMetrics) {
 		for valIdx, validator := range bundle.GetMetricsBase().NextState.Validators {
 
 			newVal := spec.ValidatorLastStatus{
-				ValIdx:          phase0.ValidatorIndex(valIdx),
-				Epoch:           bundle.GetMetricsBase().NextState.Epoch,
-				CurrentBalance:  bundle.GetMetricsBase().NextState.Balances[valIdx],
-				CurrentStatus:   bundle.GetMetricsBase().NextState.GetValStatus(phase0.ValidatorIndex(valIdx)),
-				Slashed:         validator.Slashed,
-				ActivationEpoch: validator.ActivationEpoch,
-				WithdrawalEpoch: validator.WithdrawableEpoch,
-				ExitEpoch:       validator.ExitEpoch,
-				PublicKey:       validator.PublicKey,
+				ValIdx:                phase0.ValidatorIndex(valIdx),
+				Epoch:                 bundle.GetMetricsBase().NextState.Epoch,
+				CurrentBalance:        bundle.GetMetricsBase().NextState.Balances[valIdx],
+				CurrentStatus:         bundle.GetMetricsBase().NextState.GetValStatus(phase0.ValidatorIndex(valIdx)),
+				Slashed:               validator.Slashed,
+				ActivationEpoch:       validator.ActivationEpoch,
+				WithdrawalEpoch:       validator.WithdrawableEpoch,
+				ExitEpoch:             validator.ExitEpoch,
+				PublicKey:             validator.PublicKey,
+				WithdrawalCredentials: validator.WithdrawalCredentials,
 			}
 			valStatusArr = append(valStatusArr, newVal)
 		}
@@ -162,38 +313,84 @@ func (s *ChainAnalyzer) processValLastStatus(bundle metrics.StateMetrics) {
 	}
 }
 
-func (s *ChainAnalyzer) processEpochValRewards(bundle metrics.StateMetrics) {
+func (s *ChainAnalyzer) processValStatusHistory(bundle metrics.StateMetrics) {
 
-	if s.metrics.ValidatorRewards { // only if flag is activated
-		var insertValsObj []spec.ValidatorRewards
-		log.Debugf("persising validator metrics: epoch %d", bundle.GetMetricsBase().NextState.Epoch)
+	// without currentState (first epoch processed) every validator is compared with its last stored status
+	changes := bundle.GetMetricsBase().ExportToValidatorStatusHistory()
+	if bundle.GetMetricsBase().CurrentState.EmptyStateRoot() {
+		stored, err := s.dbClient.RetrieveValidatorStatuses(bundle.GetMetricsBase().NextState.Epoch)
+		if err != nil {
+			log.Errorf("could not retrieve the stored validator statuses, writing every validator: %s", err)
+		} else {
+			changes = spec.FilterStoredStatuses(changes, stored)
+		}
+	}
 
-		// process each validator
-		for valIdx := range bundle.GetMetricsBase().NextState.Validators {
+	log.Debugf("persisting validator status history: epoch %d, %d changes", bundle.GetMetricsBase().NextState.Epoch, len(changes))
 
-			if valIdx >= len(bundle.GetMetricsBase().NextState.Validators) {
-				continue // validator is not in the chain yet
-			}
-			// get max reward at given epoch using the formulas
-			maxRewards, err := bundle.GetMaxReward(phase0.ValidatorIndex(valIdx))
+	if len(changes) > 0 {
+		err := s.dbClient.PersistValidatorStatusHistory(changes)
+		if err != nil {
+			log.Errorf("error persisting validator status history: %s", err.Error())
+		}
+	}
+}
 
-			if err != nil {
-				log.Errorf("Error obtaining max reward: %s", err.Error())
-				continue
-			}
+func (s *ChainAnalyzer) processEpochValRewards(bundle metrics.StateMetrics) {
 
-			insertValsObj = append(insertValsObj, maxRewards)
-		}
-		if len(insertValsObj) > 0 { // persist everything
-			err := s.dbClient.PersistValidatorRewards(insertValsObj)
+	if s.metrics.ValidatorRewards { // only if flag is activated
+		log.Debugf("persising validator metrics: epoch %d", bundle.GetMetricsBase().NextState.Epoch)
+
+		computeValRewards(bundle, s.workerNum, valRewardsChunkSize, func(chunk []spec.ValidatorRewards) {
+			err := s.dbClient.PersistValidatorRewards(chunk)
 			if err != nil {
 				// was fatal
 				log.Errorf("error persisting validator rewards: %s", err.Error())
 			}
+		})
+	}
+}
 
-		}
+// Splits the validator set across workers, each of them calls persist every chunkSize validators
+// The chunk is reused after persist returns, so persist must not keep it
+func computeValRewards(
+	bundle metrics.StateMetrics,
+	workers int,
+	chunkSize int,
+	persist func([]spec.ValidatorRewards)) {
+
+	valIdxs := make([]phase0.ValidatorIndex, len(bundle.GetMetricsBase().NextState.Validators))
+	for i := range valIdxs {
+		valIdxs[i] = phase0.ValidatorIndex(i)
+	}
 
+	var wg sync.WaitGroup
+	for _, batch := range utils.DivideValidatorsBatches(valIdxs, workers) {
+		wg.Add(1)
+		go func(batchIdxs []phase0.ValidatorIndex) {
+			defer wg.Done()
+
+			chunk := make([]spec.ValidatorRewards, 0, min(chunkSize, len(batchIdxs)))
+			for _, valIdx := range batchIdxs {
+				// get max reward at given epoch using the formulas
+				maxRewards, err := bundle.GetMaxReward(valIdx)
+				if err != nil {
+					log.Errorf("Error obtaining max reward: %s", err.Error())
+					continue
+				}
+
+				chunk = append(chunk, maxRewards)
+				if len(chunk) >= chunkSize {
+					persist(chunk)
+					chunk = chunk[:0]
+				}
+			}
+			if len(chunk) > 0 {
+				persist(chunk)
+			}
+		}(batch.ValIdxs)
 	}
+	wg.Wait()
 }
 
 func (s *ChainAnalyzer) processBlockRewards(bundle metrics.StateMetrics) {
@@ -0,0 +1,155 @@
+package analyzer
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/clientapi"
+	"github.com/migalabs/goteth/pkg/db"
+	"github.com/migalabs/goteth/pkg/spec"
+	"github.com/migalabs/goteth/pkg/spec/metrics"
+	"github.com/migalabs/goteth/pkg/spec/spectest"
+	"github.com/stretchr/testify/assert"
+)
+
+// Bundle with a synthetic validator set, GetMaxReward goes through the blocks as the real ones do
+type syntheticRewardsBundle struct {
+	base metrics.StateMetricsBase
+}
+
+func newSyntheticRewardsBundle(numValidators int) syntheticRewardsBundle {
+	state := &spec.AgnosticState{
+		Validators: make([]*phase0.Validator, numValidators),
+		Balances:   make([]phase0.Gwei, numValidators),
+		Blocks:     make([]*spec.AgnosticBlock, spec.SlotsPerEpoch),
+	}
+	for i := range state.Validators {
+		state.Validators[i] = &phase0.Validator{EffectiveBalance: 32 * spec.EffectiveBalanceInc}
+		state.Balances[i] = 32 * spec.EffectiveBalanceInc
+	}
+	for i := range state.Blocks {
+		state.Blocks[i] = &spec.AgnosticBlock{Proposed: true, ProposerIndex: phase0.ValidatorIndex(i)}
+	}
+	return syntheticRewardsBundle{
+		base: metrics.StateMetricsBase{NextState: state, CurrentState: state, PrevState: state},
+	}
+}
+
+func (b syntheticRewardsBundle) GetMetricsBase() metrics.StateMetricsBase {
+	return b.base
+}
+
+func (b syntheticRewardsBundle) GetMaxReward(valIdx phase0.ValidatorIndex) (spec.ValidatorRewards, error) {
+	proposerReward := phase0.Gwei(0)
+	for _, block := range b.base.NextState.Blocks {
+		if block.Proposed && block.ProposerIndex == valIdx {
+			proposerReward += block.ManualReward
+		}
+	}
+	return spec.ValidatorRewards{
+		ValidatorIndex:   valIdx,
+		Epoch:            b.base.NextState.Epoch,
+		ValidatorBalance: b.base.NextState.Balances[valIdx],
+		MaxReward:        proposerReward,
+		Status:           b.base.NextState.GetValStatus(valIdx),
+	}, nil
+}
+
+func TestComputeValRewards(t *testing.T) {
+	bundle := newSyntheticRewardsBundle(10001)
+
+	var mu sync.Mutex
+	seen := make(map[phase0.ValidatorIndex]int)
+	computeValRewards(bundle, 4, 1000, func(chunk []spec.ValidatorRewards) {
+		assert.LessOrEqual(t, len(chunk), 1000)
+		mu.Lock()
+		for _, item := range chunk {
+			seen[item.ValidatorIndex]++
+		}
+		mu.Unlock()
+	})
+
+	assert.Equal(t, 10001, len(seen))
+	for valIdx, count := range seen {
+		assert.Equal(t, 1, count, "validator %d", valIdx)
+	}
+}
+
+// workers=1 with a chunk of the whole validator set is the previous serial behaviour
+func BenchmarkComputeValRewards(b *testing.B) {
+	bundle := newSyntheticRewardsBundle(ValidatorSetSize)
+
+	for _, workers := range []int{1, 4, 8} {
+		for _, chunkSize := range []int{ValidatorSetSize, valRewardsChunkSize} {
+			b.Run(fmt.Sprintf("workers=%d/chunk=%d", workers, chunkSize), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					computeValRewards(bundle, workers, chunkSize, func(chunk []spec.ValidatorRewards) {})
+				}
+			})
+		}
+	}
+}
+
+// Transition into epoch 5, the first epoch processed is 4 so there are no rewards to compute
+func TestProcessStateTransitionMetrics(t *testing.T) {
+	s, store := buildMemoryChainAnalyzer(t)
+	s.cli = &clientapi.APIClient{}
+	s.metrics = db.DBMetrics{Epoch: true}
+	s.initSlot = 4 * spec.SlotsPerEpoch
+
+	currentState := spectest.State(4, 2)
+	nextState := spectest.State(5, 2)
+	for i := 0; i < 2; i++ {
+		nextState.EpochStructs.ProposerDuties = append(nextState.EpochStructs.ProposerDuties, &api.ProposerDuty{
+			ValidatorIndex: phase0.ValidatorIndex(i),
+			Slot:           5*spec.SlotsPerEpoch + phase0.Slot(i),
+		})
+	}
+	nextState.MissedBlocks = []phase0.Slot{5*spec.SlotsPerEpoch + 1}
+	nextState.Validators[1].ExitEpoch = 6
+	nextState.Validators[0].EffectiveBalance = 31_000_000_000
+	s.downloadCache.StateHistory.Set(4, currentState)
+	s.downloadCache.StateHistory.Set(5, nextState)
+
+	s.ProcessStateTransitionMetrics(5)
+
+	epochs := store.Rows("t_epoch_metrics_summary")
+	if assert.Len(t, epochs, 1) {
+		assert.Equal(t, uint64(4), epochs[0]["f_epoch"])
+		assert.Equal(t, uint64(2), epochs[0]["f_num_vals"])
+	}
+
+	duties := store.Rows("t_proposer_duties")
+	if assert.Len(t, duties, 2) {
+		assert.Equal(t, true, duties[0]["f_proposed"])
+		assert.Equal(t, false, duties[1]["f_proposed"])
+	}
+
+	finality := store.Rows("t_epoch_finality")
+	if assert.Len(t, finality, 1) {
+		assert.Equal(t, uint64(5), finality[0]["f_epoch"])
+		assert.Equal(t, uint64(3), finality[0]["f_finalized_epoch"])
+	}
+
+	events := store.Rows("t_validator_events")
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, uint64(1), events[0]["f_val_idx"])
+	}
+	history := store.Rows("t_validator_status_history")
+	if assert.Len(t, history, 1) {
+		assert.Equal(t, uint64(0), history[0]["f_val_idx"])
+	}
+
+	// a reorged state is deleted and processed again, as HandleReorg does once it is downloaded
+	assert.NoError(t, store.DeleteStateMetrics(5))
+	s.ProcessStateTransitionMetrics(5)
+
+	assert.Len(t, store.Rows("t_proposer_duties"), 2)
+	assert.Len(t, store.Rows("t_epoch_finality"), 1)
+	assert.Len(t, store.Rows("t_validator_events"), 1)
+	assert.Len(t, store.Rows("t_validator_status_history"), 1)
+}
@@ -25,6 +25,17 @@ var (
 		Name:      "block_queue_length",
 		Help:      "The number of blocks int the history queue",
 	})
+	RewardsMismatches = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: strings.ToLower(utils.CliName),
+			Subsystem: modName,
+			Name:      "rewards_mismatches",
+			Help:      "The number of validator reward components that differ from the Beacon API",
+		},
+		[]string{
+			"component",
+		},
+	)
 )
 
 func (c *ChainAnalyzer) GetPrometheusMetrics() *metrics.MetricsModule {
@@ -36,6 +47,9 @@ func (c *ChainAnalyzer) GetPrometheusMetrics() *metrics.MetricsModule {
 
 	metricsMod.AddIndvMetric(c.getStateHistoryLength())
 	metricsMod.AddIndvMetric(c.getBlockHistoryLength())
+	if c.metrics.RewardsAudit {
+		metricsMod.AddIndvMetric(c.getRewardsMismatches())
+	}
 
 	return metricsMod
 }
@@ -91,3 +105,27 @@ func (p *ChainAnalyzer) getBlockHistoryLength() *metrics.IndvMetrics {
 
 	return indvMetr
 }
+
+func (p *ChainAnalyzer) getRewardsMismatches() *metrics.IndvMetrics {
+
+	initFn := func() error {
+		prometheus.MustRegister(RewardsMismatches)
+		return nil
+	}
+
+	updateFn := func() (interface{}, error) {
+		return nil, nil // the counter is increased while auditing rewards
+	}
+
+	indvMetr, err := metrics.NewIndvMetrics(
+		"rewards_mismatches",
+		initFn,
+		updateFn,
+	)
+	if err != nil {
+		log.Error(errors.Wrap(err, "unable to init rewards_mismatches"))
+		return nil
+	}
+
+	return indvMetr
+}
@@ -20,7 +20,8 @@ func (s *ChainAnalyzer) AdvanceFinalized(newFinalizedSlot phase0.Slot) {
 		if epoch >= uint64(finalizedEpoch) {
 			continue // only process epochs that are before the given epoch
 		}
-		advance = true // only set flag if there is something to do
+		advance = true    // only set flag if there is something to do
+		rewritten := true // false when the reorged rows of the epoch could not be rewritten
 
 		// Retrieve stored root and redownload root once finalized
 		cacheState := s.downloadCache.StateHistory.Wait(epoch)
@@ -32,10 +33,15 @@ func (s *ChainAnalyzer) AdvanceFinalized(newFinalizedSlot phase0.Slot) {
 				log.Warnf("cache state root: %s\nfinalized block root: %s", *cacheStateRoot, *finalizedStateRoot)
 				log.Warnf("state root for state (slot=%d) incorrect, redownload", cacheState.Slot)
 
-				s.dbClient.DeleteStateMetrics(phase0.Epoch(epoch))
-				log.Infof("rewriting metrics for epoch %d", epoch)
-				// write epoch metrics
-				s.ProcessStateTransitionMetrics(phase0.Epoch(epoch))
+				err := s.dbClient.DeleteStateMetrics(phase0.Epoch(epoch))
+				if err != nil {
+					log.Errorf("could not delete the metrics of epoch %d, they are not rewritten: %s", epoch, err)
+					rewritten = false
+				} else {
+					log.Infof("rewriting metrics for epoch %d", epoch)
+					// write epoch metrics
+					s.ProcessStateTransitionMetrics(phase0.Epoch(epoch))
+				}
 			}
 		}
 
@@ -51,16 +57,27 @@ func (s *ChainAnalyzer) AdvanceFinalized(newFinalizedSlot phase0.Slot) {
 				log.Warnf("cache block root: %s\nfinalized block root: %s", cacheBlockRoot, finalizedBlockRoot)
 				log.Warnf("block root for block (slot=%d) incorrect, redownload", cacheBlock.Slot)
 
-				s.dbClient.DeleteBlockMetrics(phase0.Slot(slot))
-				log.Infof("rewriting metrics for slot %d", slot)
-
-				s.dbClient.DeleteAttestationsMetrics(phase0.Slot(slot))
-				log.Infof("rewriting attestatiins for slot %d", slot)
+				err := s.deleteSlotMetrics(phase0.Slot(slot))
+				if err != nil {
+					log.Errorf("could not delete the metrics of slot %d, they are not rewritten: %s", slot, err)
+					rewritten = false
+					continue
+				}
 
 				// write slot metrics
 				s.ProcessBlock(phase0.Slot(slot))
 			}
 		}
+
+		// roots of the epoch are checked, its rows will not change anymore
+		if !rewritten {
+			log.Errorf("epoch %d is not marked as finalized, some of its rows were not rewritten", epoch)
+			continue
+		}
+		err := s.dbClient.MarkFinalized(phase0.Epoch(epoch))
+		if err != nil {
+			log.Errorf("could not mark epoch %d as finalized: %s", epoch, err)
+		}
 	}
 
 	s.downloadCache.CleanUpTo(newFinalizedSlot)
@@ -96,14 +113,13 @@ func (s *ChainAnalyzer) HandleReorg(newReorg v1.ChainReorgEvent) {
 			if block.Proposed { // keep orphans -> if previous block was proposed and roots have changed
 				s.dbClient.PersistOrphans([]spec.AgnosticBlock{oldBlock})
 			}
-			s.dbClient.DeleteBlockMetrics(i)
-			log.Infof("rewriting metrics for slot %d", i)
-
-			s.dbClient.DeleteAttestationsMetrics(i)
-			log.Infof("rewriting attestatiins for slot %d", i)
-
-			// write slot metrics
-			s.ProcessBlock(i)
+			err := s.deleteSlotMetrics(i)
+			if err != nil {
+				log.Errorf("could not delete the metrics of slot %d, they are not rewritten: %s", i, err)
+			} else {
+				// write slot metrics
+				s.ProcessBlock(i)
+			}
 		} else {
 			log.Infof("reorg slot %d: block roots are the same", i)
 		}
@@ -118,13 +134,34 @@ func (s *ChainAnalyzer) HandleReorg(newReorg v1.ChainReorgEvent) {
 			newState := s.downloadCache.StateHistory.Wait(EpochTo[uint64](epoch))
 
 			if newState.StateRoot != oldState.StateRoot {
-				s.dbClient.DeleteStateMetrics(epoch)
-				log.Infof("rewriting metrics for epoch %d", epoch)
-				// write epoch metrics
-				s.ProcessStateTransitionMetrics(epoch)
+				err := s.dbClient.DeleteStateMetrics(epoch)
+				if err != nil {
+					log.Errorf("could not delete the metrics of epoch %d, they are not rewritten: %s", epoch, err)
+				} else {
+					log.Infof("rewriting metrics for epoch %d", epoch)
+					// write epoch metrics
+					s.ProcessStateTransitionMetrics(epoch)
+				}
 			}
 		}
 		i -= 1
 	}
 
 }
+
+// Deletes the rows of the reorged slot before it is written again.
+// Fails while spilled rows of the slot could not be replayed, as they would be replayed on top of the new ones
+func (s *ChainAnalyzer) deleteSlotMetrics(slot phase0.Slot) error {
+	err := s.dbClient.DeleteBlockMetrics(slot)
+	if err != nil {
+		return err
+	}
+	log.Infof("rewriting metrics for slot %d", slot)
+
+	err = s.dbClient.DeleteAttestationsMetrics(slot)
+	if err != nil {
+		return err
+	}
+	log.Infof("rewriting attestations for slot %d", slot)
+	return nil
+}
@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/spec"
+	"github.com/migalabs/goteth/pkg/spec/metrics"
+)
+
+// Compares the reward components computed by goteth with the ones returned by the Beacon API
+// and persists every mismatch
+func (s *ChainAnalyzer) processRewardsAudit(bundle metrics.StateMetrics) {
+
+	nextState := bundle.GetMetricsBase().NextState
+	if len(nextState.InactivityScores) == 0 {
+		return // reward components are only computed from Altair onwards
+	}
+
+	computedRewards := make(map[phase0.ValidatorIndex]spec.ValidatorRewards)
+	getComputedRewards := func(valIdx phase0.ValidatorIndex) (spec.ValidatorRewards, bool) {
+		if int(valIdx) >= len(nextState.Validators) {
+			return spec.ValidatorRewards{}, false
+		}
+		rewards, ok := computedRewards[valIdx]
+		if !ok {
+			var err error
+			rewards, err = bundle.GetMaxReward(valIdx)
+			if err != nil {
+				log.Errorf("error obtaining rewards of validator %d: %s", valIdx, err)
+				return spec.ValidatorRewards{}, false
+			}
+			computedRewards[valIdx] = rewards
+		}
+		return rewards, true
+	}
+
+	audits := make([]spec.RewardsAudit, 0)
+	addAudit := func(valIdx phase0.ValidatorIndex, component string, apiReward int64, computedReward int64) {
+		if apiReward == computedReward {
+			return
+		}
+		RewardsMismatches.WithLabelValues(component).Inc()
+		audits = append(audits, spec.RewardsAudit{
+			ValIdx:         valIdx,
+			Epoch:          nextState.Epoch,
+			Component:      component,
+			ApiReward:      apiReward,
+			ComputedReward: computedReward,
+		})
+	}
+
+	// attestation rewards at nextState belong to the attestations of prevState epoch
+	attRewards, err := s.cli.RequestAttestationRewards(bundle.GetMetricsBase().PrevState.Epoch)
+	if err != nil {
+		log.Errorf("could not audit attestation rewards: %s", err)
+	}
+	for _, apiReward := range attRewards.Data.TotalRewards {
+		valIdx := phase0.ValidatorIndex(apiReward.ValidatorIndex)
+		rewards, ok := getComputedRewards(valIdx)
+		if !ok {
+			continue
+		}
+		addAudit(valIdx, "source", apiReward.Source, rewards.SourceReward)
+		addAudit(valIdx, "target", apiReward.Target, rewards.TargetReward)
+		addAudit(valIdx, "head", apiReward.Head, rewards.HeadReward)
+		addAudit(valIdx, "inactivity", apiReward.Inactivity, rewards.InactivityReward)
+	}
+
+	syncRewards := make(map[phase0.ValidatorIndex]int64)
+	for _, block := range nextState.Blocks {
+		if !block.Proposed {
+			continue
+		}
+		blockSyncRewards, err := s.cli.RequestSyncCommitteeRewards(block.Slot)
+		if err != nil {
+			log.Errorf("could not audit sync committee rewards: %s", err)
+			continue
+		}
+		for _, apiReward := range blockSyncRewards.Data {
+			syncRewards[phase0.ValidatorIndex(apiReward.ValidatorIndex)] += apiReward.Reward
+		}
+	}
+	for valIdx, apiReward := range syncRewards {
+		rewards, ok := getComputedRewards(valIdx)
+		if !ok {
+			continue
+		}
+		addAudit(valIdx, "sync", apiReward, rewards.SyncReward)
+	}
+
+	log.Debugf("persisting rewards audit: epoch %d, %d mismatches", nextState.Epoch, len(audits))
+
+	if len(audits) > 0 {
+		err := s.dbClient.PersistRewardsAudit(audits)
+		if err != nil {
+			log.Errorf("error persisting rewards audit: %s", err.Error())
+		}
+	}
+}
@@ -155,6 +155,14 @@ func (s *ChainAnalyzer) runHistorical(init phase0.Slot, end phase0.Slot) {
 
 	log.Infof("Switch to historical mode: %d - %d", init, end)
 
+	// the first slots may not start an epoch, seed the finalized epoch before downloading them
+	finalizedBlock, err := s.cli.RequestFinalizedBeaconBlock()
+	if err != nil {
+		log.Errorf("could not request finalized slot: %s", err)
+	} else if init < finalizedBlock.Slot {
+		s.seedFinalized(finalizedBlock.Slot)
+	}
+
 	i := init
 	for i <= end {
 		if s.stop {
@@ -178,11 +186,17 @@ func (s *ChainAnalyzer) runHistorical(init phase0.Slot, end phase0.Slot) {
 				if i >= finalizedSlot.Slot {
 					// keep 2 epochs before finalized, needed to calculate epoch metrics
 					s.AdvanceFinalized(finalizedSlot.Slot - spec.SlotsPerEpoch*5) // includes check and clean
-				} else if i > (5 * spec.SlotsPerEpoch) {
-					// keep 5 epochs before current downloading slot, need 3 at least for epoch metrics
-					// magic number, 2 extra if processer takes long
-					cleanUpToSlot := i - (5 * spec.SlotsPerEpoch)
-					s.downloadCache.CleanUpTo(cleanUpToSlot) // only clean, no check, keep
+				} else {
+					// slots before the finalized one are downloaded once finalized, no check needed:
+					// their rows are written finalized instead of being marked afterwards
+					s.seedFinalized(finalizedSlot.Slot)
+
+					if i > (5 * spec.SlotsPerEpoch) {
+						// keep 5 epochs before current downloading slot, need 3 at least for epoch metrics
+						// magic number, 2 extra if processer takes long
+						cleanUpToSlot := i - (5 * spec.SlotsPerEpoch)
+						s.downloadCache.CleanUpTo(cleanUpToSlot) // only clean, no check, keep
+					}
 				}
 
 			}
@@ -196,3 +210,12 @@ func (s *ChainAnalyzer) runHistorical(init phase0.Slot, end phase0.Slot) {
 	log.Infof("historical mode: all download tasks sent")
 
 }
+
+// Rows of the epochs before the finalized slot are written finalized from now on
+func (s *ChainAnalyzer) seedFinalized(finalizedSlot phase0.Slot) {
+	finalizedEpoch := phase0.Epoch(finalizedSlot / spec.SlotsPerEpoch)
+	if finalizedEpoch == 0 {
+		return
+	}
+	s.dbClient.SetFinalized(finalizedEpoch - 1)
+}
@@ -16,6 +16,7 @@ const (
 	minStateReqTime            = 1 * time.Second        // max 1 query per second, dont spam beacon node
 	epochsToFinalizedTentative = 3                      // usually, 2 full epochs before the head it is finalized
 	dataWaitInterval           = 1 * time.Minute        // wait for block or epoch to be in the cache
+	valRewardsChunkSize        = 10000                  // max validator rewards persisted in a single insert
 )
 
 var (
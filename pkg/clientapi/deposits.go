@@ -0,0 +1,120 @@
+package clientapi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	// keccak256("DepositEvent(bytes,bytes,bytes,bytes,bytes)")
+	depositEventTopic     = common.HexToHash("0x649bbc62d0e31342afea4e5cd82d4049e7e1ee912fc0889aa790803be39038c5")
+	depositLogsWindow     = uint64(1000) // blocks requested per eth_getLogs call
+	maxDepositLogsWindows = 100          // give up after looking back this many windows
+)
+
+func (s *APIClient) RequestDepositContract() (common.Address, error) {
+	contract, err := s.Api.DepositContract(s.ctx, &api.DepositContractOpts{})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("could not request deposit contract: %s", err)
+	}
+	return common.BytesToAddress(contract.Data.Address), nil
+}
+
+// Looks for the DepositEvent logs of the given deposit indexes, walking the execution chain backwards
+// from the block where the deposits were voted (eth1 data block hash)
+func (s *APIClient) RequestDepositEvents(eth1BlockHash []byte, fromIndex uint64, toIndex uint64) (map[uint64]spec.DepositEvent, error) {
+
+	events := make(map[uint64]spec.DepositEvent)
+	if s.ELApi == nil {
+		return events, nil
+	}
+
+	contract, err := s.RequestDepositContract()
+	if err != nil {
+		return events, err
+	}
+
+	header, err := s.ELApi.HeaderByHash(s.ctx, common.BytesToHash(eth1BlockHash))
+	if err != nil {
+		return events, fmt.Errorf("could not request eth1 data block %#x: %s", eth1BlockHash, err)
+	}
+
+	toBlock := header.Number.Uint64()
+	for i := 0; i < maxDepositLogsWindows; i++ {
+		fromBlock := uint64(0)
+		if toBlock > depositLogsWindow {
+			fromBlock = toBlock - depositLogsWindow
+		}
+
+		logs, err := s.ELApi.FilterLogs(s.ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(fromBlock),
+			ToBlock:   new(big.Int).SetUint64(toBlock),
+			Addresses: []common.Address{contract},
+			Topics:    [][]common.Hash{{depositEventTopic}},
+		})
+		if err != nil {
+			return events, fmt.Errorf("could not request deposit logs from block %d to %d: %s", fromBlock, toBlock, err)
+		}
+
+		lowestIndex := toIndex + 1
+		for _, depositLog := range logs {
+			index, err := parseDepositEventIndex(depositLog.Data)
+			if err != nil {
+				log.Warnf("could not parse deposit log in tx %s: %s", depositLog.TxHash, err)
+				continue
+			}
+			if index < lowestIndex {
+				lowestIndex = index
+			}
+			if index < fromIndex || index > toIndex {
+				continue
+			}
+			events[index] = spec.DepositEvent{
+				Index:       index,
+				BlockNumber: depositLog.BlockNumber,
+				TxHash:      depositLog.TxHash,
+				Sender:      s.requestTransactionSender(depositLog.TxHash),
+			}
+		}
+
+		// events are emitted in index order, so there is nothing else to find further back
+		if uint64(len(events)) == toIndex-fromIndex+1 || lowestIndex <= fromIndex || fromBlock == 0 {
+			break
+		}
+		toBlock = fromBlock - 1
+	}
+
+	return events, nil
+}
+
+func (s *APIClient) requestTransactionSender(txHash common.Hash) common.Address {
+	tx, _, err := s.ELApi.TransactionByHash(s.ctx, txHash)
+	if err != nil {
+		log.Warnf("could not request deposit transaction %s: %s", txHash, err)
+		return common.Address{}
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		log.Warnf("could not obtain sender of deposit transaction %s: %s", txHash, err)
+	}
+	return from
+}
+
+// The index is the last of the five dynamic bytes fields of the event, as an 8 byte little endian integer
+func parseDepositEventIndex(data []byte) (uint64, error) {
+	if len(data) < 5*32 {
+		return 0, fmt.Errorf("deposit log data too short: %d bytes", len(data))
+	}
+	offset := new(big.Int).SetBytes(data[4*32 : 5*32]).Uint64()
+	if uint64(len(data)) < offset+32+8 {
+		return 0, fmt.Errorf("deposit log index out of bounds: offset %d, %d bytes", offset, len(data))
+	}
+	return binary.LittleEndian.Uint64(data[offset+32 : offset+32+8]), nil
+}
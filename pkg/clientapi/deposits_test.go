@@ -0,0 +1,63 @@
+package clientapi
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ABI encoded DepositEvent data with the given index, each of the five bytes fields holds size bytes
+func depositEventData(index uint64, size int) []byte {
+	fieldLen := 32 + (size+31)/32*32
+	data := make([]byte, 5*32+5*fieldLen)
+	for i := 0; i < 5; i++ {
+		binary.BigEndian.PutUint64(data[i*32+24:(i+1)*32], uint64(5*32+i*fieldLen)) // offset of the field
+		binary.BigEndian.PutUint64(data[5*32+i*fieldLen+24:5*32+i*fieldLen+32], uint64(size))
+	}
+	indexOffset := 5*32 + 4*fieldLen + 32
+	binary.LittleEndian.PutUint64(data[indexOffset:indexOffset+8], index)
+	return data
+}
+
+func TestParseDepositEventIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected uint64
+		err      bool
+	}{
+		{
+			name:     "first deposit",
+			data:     depositEventData(0, 8),
+			expected: 0,
+		},
+		{
+			name:     "little endian index",
+			data:     depositEventData(1_234_567, 8),
+			expected: 1_234_567,
+		},
+		{
+			name: "too short",
+			data: make([]byte, 4*32),
+			err:  true,
+		},
+		{
+			name: "index out of bounds",
+			data: depositEventData(5, 8)[:5*32+4*64+32+4],
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			index, err := parseDepositEventIndex(test.data)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, index)
+		})
+	}
+}
@@ -34,3 +34,68 @@ func (s *APIClient) RequestBlockRewards(slot phase0.Slot) (spec.BlockRewards, er
 	return rewards, err
 
 }
+
+// Rewards of the attestations to the given epoch for every validator
+func (s *APIClient) RequestAttestationRewards(epoch phase0.Epoch) (spec.AttestationRewards, error) {
+
+	var rewards spec.AttestationRewards
+
+	uri := strings.Replace(s.Api.Address(), "xxxxx", s.Password, 1) + "/eth/v1/beacon/rewards/attestations/" + fmt.Sprintf("%d", epoch)
+	body, err := s.postAllValidators(uri)
+	if err != nil {
+		return rewards, fmt.Errorf("error requesting attestation rewards for epoch %d: %s", epoch, err)
+	}
+
+	err = json.Unmarshal(body, &rewards)
+	if err != nil {
+		log.Warnf("error parsing attestation rewards for epoch %d, response body %s: %s", epoch, string(body), err)
+	}
+
+	return rewards, err
+}
+
+// Rewards of the sync committee members in the block at the given slot
+func (s *APIClient) RequestSyncCommitteeRewards(slot phase0.Slot) (spec.SyncCommitteeRewards, error) {
+
+	var rewards spec.SyncCommitteeRewards
+
+	uri := strings.Replace(s.Api.Address(), "xxxxx", s.Password, 1) + "/eth/v1/beacon/rewards/sync_committee/" + fmt.Sprintf("%d", slot)
+	body, err := s.postAllValidators(uri)
+	if err != nil {
+		return rewards, fmt.Errorf("error requesting sync committee rewards for slot %d: %s", slot, err)
+	}
+
+	err = json.Unmarshal(body, &rewards)
+	if err != nil {
+		log.Warnf("error parsing sync committee rewards for slot %d, response body %s: %s", slot, string(body), err)
+	}
+
+	return rewards, err
+}
+
+// the rewards of every validator can take a while, but a hung beacon node must not block the audit
+var rewardsHTTPClient = &http.Client{Timeout: QueryTimeout}
+
+// an empty list of validators returns the rewards of all of them
+func (s *APIClient) postAllValidators(uri string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, uri, strings.NewReader("[]"))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := rewardsHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code %d: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
@@ -56,7 +56,7 @@ func (s *APIClient) RequestBeaconState(slot phase0.Slot) (*local_spec.AgnosticSt
 
 	log.Infof("state at slot %d downloaded in %f seconds", slot, time.Since(startTime).Seconds())
 
-	epochData := s.NewEpochData(slot)
+	epochData := s.NewEpochData(slot, s.committeesNeeded(newState.Data.Version))
 
 	resultState, err := local_spec.GetCustomState(*newState.Data, epochData)
 	if err != nil {
@@ -70,6 +70,12 @@ func (s *APIClient) RequestBeaconState(slot phase0.Slot) (*local_spec.AgnosticSt
 	return &resultState, nil
 }
 
+// Committees resolve attestations to validators: needed by the attestations table and the validator rewards,
+// and by every phase0 state, whose rewards come from the pending attestations
+func (s *APIClient) committeesNeeded(version spec.DataVersion) bool {
+	return s.Metrics.Attestations || s.Metrics.ValidatorRewards || version == spec.DataVersionPhase0
+}
+
 func (s *APIClient) RequestStateRoot(slot phase0.Slot) *phase0.Root {
 
 	root, err := s.Api.BeaconStateRoot(s.ctx, &api.BeaconStateRootOpts{
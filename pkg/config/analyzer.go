@@ -15,6 +15,11 @@ type AnalyzerConfig struct {
 	DownloadMode   string      `json:"download-mode"`
 	WorkerNum      int         `json:"worker-num"`
 	DbWorkerNum    int         `json:"db-worker-num"`
+	SpillDir       string      `json:"spill-dir"`
+	SinkBrokers    string      `json:"sink-brokers"`
+	SinkPrefix     string      `json:"sink-topic-prefix"`
+	SinkFormat     string      `json:"sink-format"`
+	SinkTables     string      `json:"sink-tables"`
 	Metrics        string      `json:"metrics"`
 	PrometheusPort int         `json:"prometheus-port"`
 	NewRelicKey    string      `json:"newrelic-key"`
@@ -33,6 +38,11 @@ func NewAnalyzerConfig() *AnalyzerConfig {
 		DownloadMode:   DefaultDownloadMode,
 		WorkerNum:      DefaultWorkerNum,
 		DbWorkerNum:    DefaultDbWorkerNum,
+		SpillDir:       DefaultSpillDir,
+		SinkBrokers:    DefaultSinkBrokers,
+		SinkPrefix:     DefaultSinkTopicPrefix,
+		SinkFormat:     DefaultSinkFormat,
+		SinkTables:     DefaultSinkTables,
 		Metrics:        DefaultMetrics,
 		PrometheusPort: DefaultPrometheusPort,
 		NewRelicKey:    "",
@@ -77,6 +87,23 @@ func (c *AnalyzerConfig) Apply(ctx *cli.Context) {
 	if ctx.IsSet("db-workers-num") {
 		c.DbWorkerNum = ctx.Int("db-workers-num")
 	}
+	// spill dir
+	if ctx.IsSet("spill-dir") {
+		c.SpillDir = ctx.String("spill-dir")
+	}
+	// event sink
+	if ctx.IsSet("sink-brokers") {
+		c.SinkBrokers = ctx.String("sink-brokers")
+	}
+	if ctx.IsSet("sink-topic-prefix") {
+		c.SinkPrefix = ctx.String("sink-topic-prefix")
+	}
+	if ctx.IsSet("sink-format") {
+		c.SinkFormat = ctx.String("sink-format")
+	}
+	if ctx.IsSet("sink-tables") {
+		c.SinkTables = ctx.String("sink-tables")
+	}
 	// metrics
 	if ctx.IsSet("metrics") {
 		c.Metrics = ctx.String("metrics")
@@ -10,7 +10,19 @@ var (
 	DefaultDownloadMode          string = "finalized"
 	DefaultWorkerNum             int    = 4
 	DefaultDbWorkerNum           int    = 4
+	DefaultSpillDir              string = "./spill"
+	DefaultSinkBrokers           string = "" // no sink
+	DefaultSinkTopicPrefix       string = "goteth"
+	DefaultSinkFormat            string = "json"
+	DefaultSinkTables            string = "t_block_metrics,t_epoch_metrics_summary,t_validator_rewards_summary,t_reorgs,t_finalized_checkpoint"
 	DefaultMetrics               string = "epoch,block"
 	DefaultPrometheusPort        int    = 9080
 	DefaultValidatorWindowEpochs int    = 100
+	DefaultValWindowTTLEpochs    int    = 0 // no TTL
+	DefaultExportTables          string = "t_validator_rewards_summary,t_block_metrics"
+	DefaultExportFromEpoch       int    = 0
+	DefaultExportToEpoch         int    = 0 // up to the last epoch in the database
+	DefaultExportFormat          string = "parquet"
+	DefaultExportOutDir          string = "./export"
+	DefaultExportEpochsPerFile   int    = 100
 )
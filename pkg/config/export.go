@@ -0,0 +1,67 @@
+package config
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	cli "github.com/urfave/cli/v2"
+)
+
+type ExportConfig struct {
+	LogLevel      string       `json:"log-level"`
+	DBUrl         string       `json:"db-url"`
+	Tables        string       `json:"tables"`
+	FromEpoch     phase0.Epoch `json:"from-epoch"`
+	ToEpoch       phase0.Epoch `json:"to-epoch"`
+	Format        string       `json:"format"`
+	OutDir        string       `json:"out"`
+	EpochsPerFile int          `json:"epochs-per-file"`
+}
+
+// TODO: read from config-file
+func NewExportConfig() *ExportConfig {
+	// Return Default values for the export configuration
+	return &ExportConfig{
+		LogLevel:      DefaultLogLevel,
+		DBUrl:         DefaultDBUrl,
+		Tables:        DefaultExportTables,
+		FromEpoch:     phase0.Epoch(DefaultExportFromEpoch),
+		ToEpoch:       phase0.Epoch(DefaultExportToEpoch),
+		Format:        DefaultExportFormat,
+		OutDir:        DefaultExportOutDir,
+		EpochsPerFile: DefaultExportEpochsPerFile,
+	}
+}
+
+func (c *ExportConfig) Apply(ctx *cli.Context) {
+	// apply to the existing Default configuration the set flags
+	// log level
+	if ctx.IsSet("log-level") {
+		c.LogLevel = ctx.String("log-level")
+	}
+	// db url
+	if ctx.IsSet("db-url") {
+		c.DBUrl = ctx.String("db-url")
+	}
+	// tables to export
+	if ctx.IsSet("tables") {
+		c.Tables = ctx.String("tables")
+	}
+	// epoch range
+	if ctx.IsSet("from-epoch") {
+		c.FromEpoch = phase0.Epoch(ctx.Int("from-epoch"))
+	}
+	if ctx.IsSet("to-epoch") {
+		c.ToEpoch = phase0.Epoch(ctx.Int("to-epoch"))
+	}
+	// file format
+	if ctx.IsSet("format") {
+		c.Format = ctx.String("format")
+	}
+	// output directory
+	if ctx.IsSet("out") {
+		c.OutDir = ctx.String("out")
+	}
+	// epochs per file
+	if ctx.IsSet("epochs-per-file") {
+		c.EpochsPerFile = ctx.Int("epochs-per-file")
+	}
+}
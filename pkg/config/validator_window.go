@@ -8,6 +8,7 @@ type ValidatorWindowConfig struct {
 	LogLevel   string `json:"log-level"`
 	DBUrl      string `json:"db-url"`
 	NumEpochs  int    `json:"num-epochs"`
+	TTLEpochs  int    `json:"ttl-epochs"`
 	BnEndpoint string `json:"bn-endpoint"`
 }
 
@@ -18,6 +19,7 @@ func NewValidatorWindowConfig() *ValidatorWindowConfig {
 		LogLevel:   DefaultLogLevel,
 		DBUrl:      DefaultDBUrl,
 		NumEpochs:  DefaultValidatorWindowEpochs,
+		TTLEpochs:  DefaultValWindowTTLEpochs,
 		BnEndpoint: DefaultBnEndpoint,
 	}
 }
@@ -36,6 +38,10 @@ func (c *ValidatorWindowConfig) Apply(ctx *cli.Context) {
 	if ctx.IsSet("num-epochs") {
 		c.NumEpochs = ctx.Int("num-epochs")
 	}
+	// ttl of the partitioned tables
+	if ctx.IsSet("ttl-epochs") {
+		c.TTLEpochs = ctx.Int("ttl-epochs")
+	}
 	// cl url
 	if ctx.IsSet("bn-endpoint") {
 		c.BnEndpoint = ctx.String("bn-endpoint")
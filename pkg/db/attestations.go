@@ -81,9 +81,10 @@ func attestationInput(attestations []spec.Attestation) proto.Input {
 	}
 }
 
+// delete attestations included at the slot, versioned tables keep them as not canonical
 func (s *DBService) DeleteAttestationsMetrics(slot phase0.Slot) error {
 
-	err := s.Delete(DeletableObject{
+	err := s.Supersede(DeletableObject{
 		query: deleteAttestationQuery,
 		table: attestationsTable,
 		args:  []any{slot},
@@ -117,7 +118,7 @@ func (p *DBService) PersistAttestations(data []spec.AgnosticBlock) error {
 		persistObj.Append(att)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting attestations: %s", err.Error())
 	}
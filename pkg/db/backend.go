@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Operations that depend on the database engine, the rest of the DBService is shared
+type dbBackend interface {
+	name() string
+	connect(ctx context.Context) error // opens the connection used for selects and deletes
+	migrate() error
+	newWriter(ctx context.Context) (dbWriter, error)
+	exec(ctx context.Context, query string, args ...any) error
+	query(ctx context.Context, dest any, query string, args ...any) error
+	stream(ctx context.Context, query string, args []any, fn func(columns []Column, row []any) error) error // calls fn once per row
+	close()
+}
+
+// Connection used by a single db writer to insert batches
+type dbWriter interface {
+	insert(ctx context.Context, task writeTask) error
+	isClosed() bool
+	close()
+}
+
+// The backend is selected by the scheme of the database url
+func newBackend(url string) (dbBackend, error) {
+	scheme, _, found := strings.Cut(url, "://")
+	if !found {
+		return nil, fmt.Errorf("could not parse database url, expected <scheme>://user:password@host/database")
+	}
+
+	switch scheme {
+	case clickhouseScheme:
+		return newClickhouseBackend(url), nil
+	case postgresScheme, postgresqlScheme:
+		return newPostgresBackend(url), nil
+	case memoryScheme:
+		return newMemoryBackend(url), nil
+	default:
+		return nil, fmt.Errorf("unsupported database %s, use %s, %s or %s", scheme, clickhouseScheme, postgresScheme, memoryScheme)
+	}
+}
+
+type rowColumn[T any] interface {
+	Row(i int) T
+}
+
+// Returns the row of a ClickHouse column as a Go value, for the backends that do not speak the native format
+func columnValue(col proto.ColInput, i int) (any, error) {
+	switch c := col.(type) {
+	case rowColumn[uint64]:
+		return c.Row(i), nil
+	case rowColumn[uint16]:
+		return c.Row(i), nil
+	case rowColumn[uint8]:
+		return c.Row(i), nil
+	case rowColumn[int64]:
+		return c.Row(i), nil
+	case rowColumn[float32]:
+		return c.Row(i), nil
+	case rowColumn[bool]:
+		return c.Row(i), nil
+	case rowColumn[string]:
+		return c.Row(i), nil
+	case rowColumn[[]string]:
+		return c.Row(i), nil
+	default:
+		return nil, fmt.Errorf("unsupported column type %s", col.Type())
+	}
+}
+
+// Row of a table, column name to value
+type Row map[string]any
+
+// Rows of the batch
+func taskRows(task writeTask) ([]Row, error) {
+	rows := make([]Row, task.rows)
+	for i := range rows {
+		rows[i] = make(Row, len(task.input))
+		for _, col := range task.input {
+			value, err := columnValue(col.Data, i)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %s", col.Name, err)
+			}
+			rows[i][col.Name] = value
+		}
+	}
+	return rows, nil
+}
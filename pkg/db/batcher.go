@@ -0,0 +1,98 @@
+package db
+
+import (
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+var (
+	batchFlushInterval = 30 * time.Second // max time rows wait in the buffer
+)
+
+// Rows accumulated for a single table, waiting to be inserted
+type persistBuffer interface {
+	Rows() int
+	ExportPersist() (string, string, proto.Input, int)
+}
+
+// Adds the rows to the buffer of the table, the buffer is inserted once it reaches MAX_BATCH_QUEUE rows,
+// after batchFlushInterval, at the end of every MAX_EPOCH_BATCH_QUEUE epochs or when the service finishes
+func bufferPersist[T Persistable](p *DBService, obj PersistableObject[T]) error {
+	if obj.Rows() == 0 {
+		return nil
+	}
+
+	p.bufferMu.Lock()
+	buffer, ok := p.buffers[obj.table]
+	if !ok {
+		buffer = &PersistableObject[T]{
+			table: obj.table,
+			query: obj.query,
+			input: obj.input,
+		}
+		p.buffers[obj.table] = buffer
+	}
+	tableBuffer := buffer.(*PersistableObject[T])
+	tableBuffer.data = append(tableBuffer.data, obj.data...)
+
+	if tableBuffer.Rows() < MAX_BATCH_QUEUE {
+		p.bufferMu.Unlock()
+		return nil
+	}
+	delete(p.buffers, obj.table)
+	p.bufferMu.Unlock()
+
+	return p.Persist(tableBuffer.ExportPersist())
+}
+
+// Queues every buffered row to the db writers, without waiting for them to be written
+func (p *DBService) queueBuffers() {
+	p.bufferMu.Lock()
+	buffers := p.buffers
+	p.buffers = make(map[string]persistBuffer)
+	p.bufferMu.Unlock()
+
+	for _, buffer := range buffers {
+		err := p.Persist(buffer.ExportPersist())
+		if err != nil {
+			log.Errorf("could not queue buffered rows: %s", err)
+		}
+	}
+}
+
+// Inserts every buffered row and waits until every queued batch is written
+func (p *DBService) Flush() {
+	p.queueBuffers()
+	p.waitWrites()
+}
+
+// Signals that the metrics of an epoch were processed, buffers are queued every MAX_EPOCH_BATCH_QUEUE epochs.
+// It does not wait for the inserts, it only blocks while the write queue is full
+func (p *DBService) EndEpoch() {
+	p.bufferMu.Lock()
+	p.bufferedEpochs++
+	flush := p.bufferedEpochs >= MAX_EPOCH_BATCH_QUEUE
+	if flush {
+		p.bufferedEpochs = 0
+	}
+	p.bufferMu.Unlock()
+
+	if flush {
+		p.queueBuffers()
+	}
+}
+
+func (p *DBService) runFlushTicker() {
+	ticker := time.NewTicker(batchFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.queueBuffers()
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+// Rows written to the table so far, without flushing the buffers
+func writtenRows(store *MemoryStore, table string) int {
+	store.waitWrites()
+	return len(store.mem.rows(table))
+}
+
+func TestBufferPersistBatchSize(t *testing.T) {
+	defer func(size int) { MAX_BATCH_QUEUE = size }(MAX_BATCH_QUEUE)
+	MAX_BATCH_QUEUE = 3
+
+	store := newTestMemoryStore(t, "")
+	defer store.Finish()
+
+	store.PersistBlocks([]spec.AgnosticBlock{testBlock(32), testBlock(33)})
+	if rows := writtenRows(store, blocksTable); rows != 0 {
+		t.Errorf("expected the blocks to stay buffered, %d were written", rows)
+	}
+
+	// the batch is inserted once it reaches the size, with every buffered row
+	store.PersistBlocks([]spec.AgnosticBlock{testBlock(34), testBlock(35)})
+	if rows := writtenRows(store, blocksTable); rows != 4 {
+		t.Errorf("expected 4 blocks once the batch size was reached, got %d", rows)
+	}
+
+	store.PersistBlocks([]spec.AgnosticBlock{testBlock(36)})
+	if rows := writtenRows(store, blocksTable); rows != 4 {
+		t.Errorf("expected the new block to stay buffered, got %d blocks", rows)
+	}
+}
+
+func TestEndEpochFlush(t *testing.T) {
+	defer func(epochs int) { MAX_EPOCH_BATCH_QUEUE = epochs }(MAX_EPOCH_BATCH_QUEUE)
+	MAX_EPOCH_BATCH_QUEUE = 2
+
+	store := newTestMemoryStore(t, "")
+	defer store.Finish()
+
+	store.PersistBlocks([]spec.AgnosticBlock{testBlock(32)})
+	store.EndEpoch()
+	if rows := writtenRows(store, blocksTable); rows != 0 {
+		t.Errorf("expected the block to stay buffered after one epoch, %d were written", rows)
+	}
+
+	store.PersistEpochs([]spec.Epoch{{Epoch: 2, Slot: 32}})
+	store.EndEpoch()
+	if rows := writtenRows(store, blocksTable); rows != 1 {
+		t.Errorf("expected the block to be written after %d epochs, got %d", MAX_EPOCH_BATCH_QUEUE, rows)
+	}
+	if rows := writtenRows(store, epochsTable); rows != 1 {
+		t.Errorf("expected the epoch to be written after %d epochs, got %d", MAX_EPOCH_BATCH_QUEUE, rows)
+	}
+
+	// the epoch count starts again after the flush
+	store.PersistBlocks([]spec.AgnosticBlock{testBlock(48)})
+	store.EndEpoch()
+	if rows := writtenRows(store, blocksTable); rows != 1 {
+		t.Errorf("expected the new block to stay buffered, got %d blocks", rows)
+	}
+}
+
+func TestFlushDrainsEveryBuffer(t *testing.T) {
+	store := newTestMemoryStore(t, "")
+	defer store.Finish()
+
+	store.PersistBlocks([]spec.AgnosticBlock{testBlock(32), testBlock(33)})
+	store.PersistEpochs([]spec.Epoch{{Epoch: 2, Slot: 32}})
+	store.PersistDuties([]spec.ProposerDuty{{ProposerSlot: 32}, {ProposerSlot: phase0.Slot(33)}})
+
+	store.Flush()
+
+	store.bufferMu.Lock()
+	buffered := len(store.buffers)
+	store.bufferMu.Unlock()
+	if buffered != 0 {
+		t.Errorf("expected every buffer to be drained, %d remain", buffered)
+	}
+
+	expected := map[string]int{blocksTable: 2, epochsTable: 1, proposerDutiesTable: 2}
+	for table, rows := range expected {
+		// Flush waits for the writes, the rows are there without waiting again
+		if written := len(store.mem.rows(table)); written != rows {
+			t.Errorf("expected %d rows in %s after flushing, got %d", rows, table, written)
+		}
+	}
+}
@@ -50,7 +50,7 @@ func (p *DBService) PersistBlobSidecarsEvents(data []spec.BlobSideCarEventWraper
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting blob events: %s", err.Error())
 	}
@@ -72,7 +72,7 @@ func (p *DBService) PersistBlobSidecars(data []*spec.AgnosticBlobSidecar) error
 		persistObj.Append(*item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting blobs: %s", err.Error())
 	}
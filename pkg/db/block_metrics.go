@@ -144,9 +144,10 @@ func blocksInput(blocks []spec.AgnosticBlock) proto.Input {
 	}
 }
 
+// delete metrics of the block at the slot, versioned tables keep them as not canonical
 func (s *DBService) DeleteBlockMetrics(slot phase0.Slot) error {
 
-	err := s.Delete(DeletableObject{
+	err := s.Supersede(DeletableObject{
 		query: deleteBlockQuery,
 		table: blocksTable,
 		args:  []any{slot},
@@ -155,7 +156,7 @@ func (s *DBService) DeleteBlockMetrics(slot phase0.Slot) error {
 		return err
 	}
 
-	err = s.Delete(DeletableObject{
+	err = s.Supersede(DeletableObject{
 		query: deleteTransactionsQuery,
 		table: transactionsTable,
 		args:  []any{slot},
@@ -163,7 +164,7 @@ func (s *DBService) DeleteBlockMetrics(slot phase0.Slot) error {
 	if err != nil {
 		return err
 	}
-	err = s.Delete(DeletableObject{
+	err = s.Supersede(DeletableObject{
 		query: deleteWithdrawalsQuery,
 		table: withdrawalsTable,
 		args:  []any{slot},
@@ -171,7 +172,23 @@ func (s *DBService) DeleteBlockMetrics(slot phase0.Slot) error {
 	if err != nil {
 		return err
 	}
-	err = s.Delete(DeletableObject{
+	err = s.Supersede(DeletableObject{
+		query: deleteVoluntaryExitsQuery,
+		table: voluntaryExitsTable,
+		args:  []any{slot},
+	})
+	if err != nil {
+		return err
+	}
+	err = s.Supersede(DeletableObject{
+		query: deleteBLSToExecutionChangesQuery,
+		table: blsToExecutionChangesTable,
+		args:  []any{slot},
+	})
+	if err != nil {
+		return err
+	}
+	err = s.Supersede(DeletableObject{
 		query: deleteBlobsQuery,
 		table: blobsTable,
 		args:  []any{slot},
@@ -193,7 +210,7 @@ func (p *DBService) PersistBlocks(data []spec.AgnosticBlock) error {
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting blocks: %s", err.Error())
 	}
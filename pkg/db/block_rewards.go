@@ -74,7 +74,7 @@ func (p *DBService) PersistBlockRewards(data []BlockReward) error {
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting block rewards: %s", err.Error())
 	}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	blsToExecutionChangesTable       = "t_bls_to_execution_changes"
+	insertBLSToExecutionChangesQuery = `
+	INSERT INTO %s (
+		f_slot,
+		f_epoch,
+		f_val_idx,
+		f_from_bls_pubkey,
+		f_to_execution_address)
+		VALUES`
+
+	deleteBLSToExecutionChangesQuery = `
+		DELETE FROM %s
+		WHERE f_slot = $1;`
+)
+
+func blsToExecutionChangesInput(changes []spec.BLSToExecutionChange) proto.Input {
+	// one object per column
+	var (
+		f_slot                 proto.ColUInt64
+		f_epoch                proto.ColUInt64
+		f_val_idx              proto.ColUInt64
+		f_from_bls_pubkey      proto.ColStr
+		f_to_execution_address proto.ColStr
+	)
+
+	for _, change := range changes {
+		f_slot.Append(uint64(change.Slot))
+		f_epoch.Append(uint64(change.Epoch))
+		f_val_idx.Append(uint64(change.ValidatorIndex))
+		f_from_bls_pubkey.Append(change.FromBLSPublicKey.String())
+		f_to_execution_address.Append(change.ToExecutionAddress.String())
+	}
+
+	return proto.Input{
+		{Name: "f_slot", Data: f_slot},
+		{Name: "f_epoch", Data: f_epoch},
+		{Name: "f_val_idx", Data: f_val_idx},
+		{Name: "f_from_bls_pubkey", Data: f_from_bls_pubkey},
+		{Name: "f_to_execution_address", Data: f_to_execution_address},
+	}
+}
+
+func (p *DBService) PersistBLSToExecutionChanges(data []spec.BLSToExecutionChange) error {
+	persistObj := PersistableObject[spec.BLSToExecutionChange]{
+		input: blsToExecutionChangesInput,
+		table: blsToExecutionChangesTable,
+		query: insertBLSToExecutionChangesQuery,
+	}
+
+	for _, item := range data {
+		persistObj.Append(item)
+	}
+
+	err := bufferPersist(p, persistObj)
+	if err != nil {
+		log.Errorf("error persisting bls to execution changes: %s", err.Error())
+	}
+	return err
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+var (
+	clickhouseScheme        = "clickhouse"
+	clickhouseMigrationsDir = "file://pkg/db/migrations"
+)
+
+// ClickHouse backend: bulk inserts through ch-go, selects and deletes through clickhouse-go
+type clickhouseBackend struct {
+	url          string
+	migrationUrl string
+
+	highLevelClient driver.Conn
+}
+
+func newClickhouseBackend(url string) *clickhouseBackend {
+	_, migrationUrl := ParseChUrlIntoOptionsLowLevel(url)
+	return &clickhouseBackend{
+		url:          url,
+		migrationUrl: migrationUrl,
+	}
+}
+
+func (b *clickhouseBackend) name() string {
+	return clickhouseScheme
+}
+
+func (b *clickhouseBackend) connect(ctx context.Context) error {
+	opts := ParseChUrlIntoOptionsHighLevel(b.url)
+	conn, err := clickhouse.Open(&opts)
+	if err != nil {
+		return err
+	}
+
+	err = conn.Ping(ctx)
+	if err != nil {
+		return err
+	}
+
+	b.highLevelClient = conn
+	log.Info("high level client is connected")
+	return nil
+}
+
+func (b *clickhouseBackend) migrate() error {
+	return runMigrations(clickhouseMigrationsDir, b.migrationUrl)
+}
+
+func (b *clickhouseBackend) newWriter(ctx context.Context) (dbWriter, error) {
+	opts, _ := ParseChUrlIntoOptionsLowLevel(b.url)
+	client, err := ch.Dial(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &clickhouseWriter{client: client}, nil
+}
+
+func (b *clickhouseBackend) exec(ctx context.Context, query string, args ...any) error {
+	return b.highLevelClient.Exec(ctx, query, args...)
+}
+
+func (b *clickhouseBackend) query(ctx context.Context, dest any, query string, args ...any) error {
+	return b.highLevelClient.Select(ctx, dest, query, args...)
+}
+
+func (b *clickhouseBackend) stream(ctx context.Context, query string, args []any, fn func(columns []Column, row []any) error) error {
+	rows, err := b.highLevelClient.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columnTypes := rows.ColumnTypes()
+	columns := make([]Column, len(columnTypes))
+	for i, columnType := range columnTypes {
+		columns[i] = Column{Name: columnType.Name(), Type: columnType.DatabaseTypeName()}
+	}
+
+	for rows.Next() {
+		dest := make([]any, len(columnTypes))
+		for i, columnType := range columnTypes {
+			dest[i] = reflect.New(columnType.ScanType()).Interface()
+		}
+
+		err = rows.Scan(dest...)
+		if err != nil {
+			return err
+		}
+
+		row := make([]any, len(dest))
+		for i := range dest {
+			row[i] = reflect.ValueOf(dest[i]).Elem().Interface()
+		}
+		err = fn(columns, row)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (b *clickhouseBackend) close() {
+	if b.highLevelClient != nil {
+		b.highLevelClient.Close()
+	}
+}
+
+// Low level connection, inserts the columns in the native format
+type clickhouseWriter struct {
+	client *ch.Client
+}
+
+func (w *clickhouseWriter) insert(ctx context.Context, task writeTask) error {
+	return w.client.Do(ctx, ch.Query{
+		Body:  task.query,
+		Input: task.input,
+	})
+}
+
+func (w *clickhouseWriter) isClosed() bool {
+	return w.client.IsClosed()
+}
+
+func (w *clickhouseWriter) close() {
+	w.client.Close()
+}
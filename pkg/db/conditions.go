@@ -0,0 +1,79 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// only the shapes of the deletes issued by the persisters are understood
+	deleteRegex    = regexp.MustCompile(`(?s)^\s*DELETE FROM (\w+)\s+WHERE (.+?)\s*;?\s*$`)
+	conditionRegex = regexp.MustCompile(`^(\w+)\s*(<=|>=|=|<|>)\s*\$(\d+)$`)
+	andRegex       = regexp.MustCompile(`\s+AND\s+`)
+)
+
+// Comparison of an unsigned column against a value, as in the WHERE clause of the deletes
+type Condition struct {
+	Column   string
+	Operator string // =, <, <=, > or >=
+	Value    uint64
+}
+
+func (c Condition) Matches(value uint64) bool {
+	switch c.Operator {
+	case "=":
+		return value == c.Value
+	case "<":
+		return value < c.Value
+	case "<=":
+		return value <= c.Value
+	case ">":
+		return value > c.Value
+	case ">=":
+		return value >= c.Value
+	default:
+		return false
+	}
+}
+
+func (c Condition) String() string {
+	return fmt.Sprintf("%s %s %d", c.Column, c.Operator, c.Value)
+}
+
+// Returns the table and the conditions of a delete query with its arguments
+func parseDelete(query string, args []any) (string, []Condition, error) {
+	match := deleteRegex.FindStringSubmatch(query)
+	if match == nil {
+		return "", nil, fmt.Errorf("could not parse delete query: %s", query)
+	}
+
+	conditions, err := parseConditions(match[2], args)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %s", err, query)
+	}
+	return match[1], conditions, nil
+}
+
+// Parses a WHERE clause made of comparisons joined by AND
+func parseConditions(where string, args []any) ([]Condition, error) {
+	var conditions []Condition
+	for _, clause := range andRegex.Split(where, -1) {
+		match := conditionRegex.FindStringSubmatch(strings.TrimSpace(clause))
+		if match == nil {
+			return nil, fmt.Errorf("unsupported condition %s", clause)
+		}
+
+		argIdx, _ := strconv.Atoi(match[3])
+		if argIdx < 1 || argIdx > len(args) {
+			return nil, fmt.Errorf("missing argument $%d", argIdx)
+		}
+		value, ok := unsignedValue(args[argIdx-1])
+		if !ok {
+			return nil, fmt.Errorf("unsupported argument %v of type %T", args[argIdx-1], args[argIdx-1])
+		}
+		conditions = append(conditions, Condition{Column: match[1], Operator: match[2], Value: value})
+	}
+	return conditions, nil
+}
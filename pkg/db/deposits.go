@@ -0,0 +1,99 @@
+package db
+
+import (
+	"encoding/hex"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	depositsTable       = "t_deposits"
+	insertDepositsQuery = `
+	INSERT INTO %s (
+		f_slot,
+		f_epoch,
+		f_index,
+		f_public_key,
+		f_withdrawal_credentials,
+		f_amount,
+		f_val_idx,
+		f_deposit_type,
+		f_el_block_number,
+		f_el_tx_hash,
+		f_el_sender)
+		VALUES`
+
+	deleteDepositsQuery = `
+		DELETE FROM %s
+		WHERE f_epoch = $1;`
+)
+
+func depositsInput(deposits []spec.Deposit) proto.Input {
+	// one object per column
+	var (
+		f_slot                   proto.ColUInt64
+		f_epoch                  proto.ColUInt64
+		f_index                  proto.ColUInt64
+		f_public_key             proto.ColStr
+		f_withdrawal_credentials proto.ColStr
+		f_amount                 proto.ColUInt64
+		f_val_idx                proto.ColUInt64
+		f_deposit_type           proto.ColUInt8
+		f_el_block_number        proto.ColUInt64
+		f_el_tx_hash             proto.ColStr
+		f_el_sender              proto.ColStr
+	)
+
+	for _, deposit := range deposits {
+		f_slot.Append(uint64(deposit.Slot))
+		f_epoch.Append(uint64(deposit.Epoch))
+		f_index.Append(deposit.Index)
+		f_public_key.Append(deposit.PublicKey.String())
+		f_withdrawal_credentials.Append("0x" + hex.EncodeToString(deposit.WithdrawalCredentials))
+		f_amount.Append(uint64(deposit.Amount))
+		f_val_idx.Append(uint64(deposit.ValidatorIndex))
+		f_deposit_type.Append(uint8(deposit.DepositType))
+		f_el_block_number.Append(deposit.ELBlockNumber)
+		if deposit.ELBlockNumber > 0 {
+			f_el_tx_hash.Append(deposit.ELTxHash.String())
+			f_el_sender.Append(deposit.ELSender.String())
+		} else {
+			// not correlated with the execution layer
+			f_el_tx_hash.Append("")
+			f_el_sender.Append("")
+		}
+	}
+
+	return proto.Input{
+		{Name: "f_slot", Data: f_slot},
+		{Name: "f_epoch", Data: f_epoch},
+		{Name: "f_index", Data: f_index},
+		{Name: "f_public_key", Data: f_public_key},
+		{Name: "f_withdrawal_credentials", Data: f_withdrawal_credentials},
+		{Name: "f_amount", Data: f_amount},
+		{Name: "f_val_idx", Data: f_val_idx},
+		{Name: "f_deposit_type", Data: f_deposit_type},
+		{Name: "f_el_block_number", Data: f_el_block_number},
+		{Name: "f_el_tx_hash", Data: f_el_tx_hash},
+		{Name: "f_el_sender", Data: f_el_sender},
+	}
+}
+
+func (p *DBService) PersistDeposits(data []spec.Deposit) error {
+	persistObj := PersistableObject[spec.Deposit]{
+		input: depositsInput,
+		table: depositsTable,
+		query: insertDepositsQuery,
+	}
+
+	for _, item := range data {
+		persistObj.Append(item)
+	}
+
+	err := bufferPersist(p, persistObj)
+	if err != nil {
+		log.Errorf("error persisting deposits: %s", err.Error())
+	}
+	return err
+}
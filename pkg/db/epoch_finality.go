@@ -0,0 +1,88 @@
+package db
+
+import (
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	epochFinalityTable       = "t_epoch_finality"
+	insertEpochFinalityQuery = `
+	INSERT INTO %s (
+		f_epoch,
+		f_slot,
+		f_justification_bits,
+		f_prev_justified_epoch,
+		f_prev_justified_root,
+		f_current_justified_epoch,
+		f_current_justified_root,
+		f_finalized_epoch,
+		f_finalized_root,
+		f_epochs_to_finality)
+		VALUES`
+
+	deleteEpochFinalityQuery = `
+		DELETE FROM %s
+		WHERE f_epoch = $1;
+`
+)
+
+func epochFinalityInput(finalities []spec.EpochFinality) proto.Input {
+	// one object per column
+	var (
+		f_epoch                   proto.ColUInt64
+		f_slot                    proto.ColUInt64
+		f_justification_bits      proto.ColUInt8
+		f_prev_justified_epoch    proto.ColUInt64
+		f_prev_justified_root     proto.ColStr
+		f_current_justified_epoch proto.ColUInt64
+		f_current_justified_root  proto.ColStr
+		f_finalized_epoch         proto.ColUInt64
+		f_finalized_root          proto.ColStr
+		f_epochs_to_finality      proto.ColUInt64
+	)
+
+	for _, finality := range finalities {
+		f_epoch.Append(uint64(finality.Epoch))
+		f_slot.Append(uint64(finality.Slot))
+		f_justification_bits.Append(finality.JustificationBits)
+		f_prev_justified_epoch.Append(uint64(finality.PreviousJustifiedCheckpoint.Epoch))
+		f_prev_justified_root.Append(finality.PreviousJustifiedCheckpoint.Root.String())
+		f_current_justified_epoch.Append(uint64(finality.CurrentJustifiedCheckpoint.Epoch))
+		f_current_justified_root.Append(finality.CurrentJustifiedCheckpoint.Root.String())
+		f_finalized_epoch.Append(uint64(finality.FinalizedCheckpoint.Epoch))
+		f_finalized_root.Append(finality.FinalizedCheckpoint.Root.String())
+		f_epochs_to_finality.Append(uint64(finality.EpochsToFinality()))
+	}
+
+	return proto.Input{
+		{Name: "f_epoch", Data: f_epoch},
+		{Name: "f_slot", Data: f_slot},
+		{Name: "f_justification_bits", Data: f_justification_bits},
+		{Name: "f_prev_justified_epoch", Data: f_prev_justified_epoch},
+		{Name: "f_prev_justified_root", Data: f_prev_justified_root},
+		{Name: "f_current_justified_epoch", Data: f_current_justified_epoch},
+		{Name: "f_current_justified_root", Data: f_current_justified_root},
+		{Name: "f_finalized_epoch", Data: f_finalized_epoch},
+		{Name: "f_finalized_root", Data: f_finalized_root},
+		{Name: "f_epochs_to_finality", Data: f_epochs_to_finality},
+	}
+}
+
+func (p *DBService) PersistEpochFinality(data []spec.EpochFinality) error {
+	persistObj := PersistableObject[spec.EpochFinality]{
+		input: epochFinalityInput,
+		table: epochFinalityTable,
+		query: insertEpochFinalityQuery,
+	}
+
+	for _, item := range data {
+		persistObj.Append(item)
+	}
+
+	err := bufferPersist(p, persistObj)
+	if err != nil {
+		log.Errorf("error persisting epoch finality: %s", err.Error())
+	}
+	return err
+}
@@ -30,7 +30,9 @@ var (
 		f_num_slashed_vals,
 		f_num_active_vals,
 		f_num_exited_vals,
-		f_num_in_activation_vals)
+		f_num_in_activation_vals,
+		f_epochs_since_finality,
+		f_inactivity_leak)
 		VALUES`
 
 	selectLastEpochQuery = `
@@ -67,6 +69,8 @@ func epochsInput(epochs []spec.Epoch) proto.Input {
 		f_num_active_vals                  proto.ColUInt64
 		f_num_exited_vals                  proto.ColUInt64
 		f_num_in_activation_vals           proto.ColUInt64
+		f_epochs_since_finality            proto.ColUInt64
+		f_inactivity_leak                  proto.ColBool
 	)
 
 	for _, epoch := range epochs {
@@ -89,6 +93,8 @@ func epochsInput(epochs []spec.Epoch) proto.Input {
 		f_num_active_vals.Append(uint64(epoch.NumActiveVals))
 		f_num_exited_vals.Append(uint64(epoch.NumExitedVals))
 		f_num_in_activation_vals.Append(uint64(epoch.NumInActivationVals))
+		f_epochs_since_finality.Append(uint64(epoch.EpochsSinceFinality))
+		f_inactivity_leak.Append(epoch.InactivityLeak)
 
 	}
 
@@ -112,6 +118,8 @@ func epochsInput(epochs []spec.Epoch) proto.Input {
 		{Name: "f_num_active_vals", Data: f_num_active_vals},
 		{Name: "f_num_exited_vals", Data: f_num_exited_vals},
 		{Name: "f_num_in_activation_vals", Data: f_num_in_activation_vals},
+		{Name: "f_epochs_since_finality", Data: f_epochs_since_finality},
+		{Name: "f_inactivity_leak", Data: f_inactivity_leak},
 	}
 }
 
@@ -126,7 +134,7 @@ func (p *DBService) PersistEpochs(data []spec.Epoch) error {
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting epoch: %s", err.Error())
 	}
@@ -150,12 +158,12 @@ func (p *DBService) RetrieveLastEpoch() (phase0.Epoch, error) {
 
 }
 
-// delete metrics that use the state at epoch x
+// delete metrics that use the state at epoch x, versioned tables keep them as not canonical
 func (s *DBService) DeleteStateMetrics(epoch phase0.Epoch) error {
 	var err error
 
 	// epochs are written at currentState using current state and nextState
-	err = s.Delete(DeletableObject{
+	err = s.Supersede(DeletableObject{
 		query: deleteEpochsQuery,
 		table: epochsTable,
 		args:  []any{epoch - 1},
@@ -164,7 +172,7 @@ func (s *DBService) DeleteStateMetrics(epoch phase0.Epoch) error {
 	if err != nil {
 		return err
 	}
-	err = s.Delete(DeletableObject{
+	err = s.Supersede(DeletableObject{
 		query: deleteEpochsQuery,
 		table: epochsTable,
 		args:  []any{epoch},
@@ -173,18 +181,130 @@ func (s *DBService) DeleteStateMetrics(epoch phase0.Epoch) error {
 		return err
 	}
 
+	// validator attestations are written at currentState using current state and nextState
+	err = s.Supersede(DeletableObject{
+		query: deleteValidatorAttestationsQuery,
+		table: valAttestationsTable,
+		args:  []any{epoch - 1},
+	}) // when deleteState -> nextState
+	if err != nil {
+		return err
+	}
+	err = s.Supersede(DeletableObject{
+		query: deleteValidatorAttestationsQuery,
+		table: valAttestationsTable,
+		args:  []any{epoch},
+	}) // when deleteState -> currentState
+	if err != nil {
+		return err
+	}
+
+	// validator events are written at nextState using currentState and nextState
+	err = s.Supersede(DeletableObject{
+		query: deleteValidatorEventsQuery,
+		table: valEventsTable,
+		args:  []any{epoch + 1},
+	}) // when deleteState -> currentState
+	if err != nil {
+		return err
+	}
+	err = s.Supersede(DeletableObject{
+		query: deleteValidatorEventsQuery,
+		table: valEventsTable,
+		args:  []any{epoch},
+	}) // when deleteState -> nextState
+	if err != nil {
+		return err
+	}
+
+	// validator status history is written at nextState using currentState and nextState
+	err = s.Supersede(DeletableObject{
+		query: deleteValidatorStatusHistoryQuery,
+		table: valStatusHistoryTable,
+		args:  []any{epoch + 1},
+	}) // when deleteState -> currentState
+	if err != nil {
+		return err
+	}
+	err = s.Supersede(DeletableObject{
+		query: deleteValidatorStatusHistoryQuery,
+		table: valStatusHistoryTable,
+		args:  []any{epoch},
+	}) // when deleteState -> nextState
+	if err != nil {
+		return err
+	}
+
+	// deposits are written at nextState using currentState and nextState
+	err = s.Supersede(DeletableObject{
+		query: deleteDepositsQuery,
+		table: depositsTable,
+		args:  []any{epoch + 1},
+	}) // when deleteState -> currentState
+	if err != nil {
+		return err
+	}
+	err = s.Supersede(DeletableObject{
+		query: deleteDepositsQuery,
+		table: depositsTable,
+		args:  []any{epoch},
+	}) // when deleteState -> nextState
+	if err != nil {
+		return err
+	}
+
+	// sync committee participation is written using nextState
+	err = s.Supersede(DeletableObject{
+		query: deleteSyncCommitteeParticipationQuery,
+		table: syncParticipationTable,
+		args:  []any{epoch},
+	})
+	if err != nil {
+		return err
+	}
+
+	// slashings are written using nextState
+	err = s.Supersede(DeletableObject{
+		query: deleteSlashingsQuery,
+		table: slashingsTable,
+		args:  []any{epoch},
+	})
+	if err != nil {
+		return err
+	}
+
+	// epoch finality is written using nextState
+	err = s.Supersede(DeletableObject{
+		query: deleteEpochFinalityQuery,
+		table: epochFinalityTable,
+		args:  []any{epoch},
+	})
+	if err != nil {
+		return err
+	}
+
+	// rewards audit is written using nextState
+	err = s.Supersede(DeletableObject{
+		query: deleteRewardsAuditQuery,
+		table: rewardsAuditTable,
+		args:  []any{epoch},
+	})
+	if err != nil {
+		return err
+	}
+
 	// proposer duties are writter using nextState
-	err = s.Delete(DeletableObject{
+	err = s.Supersede(DeletableObject{
 		query: deleteProposerDutiesQuery,
 		table: proposerDutiesTable,
-		args:  []any{epoch},
+		args:  []any{phase0.Slot(epoch) * spec.SlotsPerEpoch, phase0.Slot(epoch+1) * spec.SlotsPerEpoch},
 	})
 	if err != nil {
 		return err
 	}
 
 	// valRewards are written at nextState using prevState, currentState and nextState
-	err = s.Delete(DeletableObject{
+	err = s.Supersede(DeletableObject{
 		query: deleteValidatorRewardsInEpochQuery,
 		table: valRewardsTable,
 		args:  []any{epoch + 2},
@@ -192,7 +312,7 @@ func (s *DBService) DeleteStateMetrics(epoch phase0.Epoch) error {
 	if err != nil {
 		return err
 	}
-	s.Delete(DeletableObject{
+	err = s.Supersede(DeletableObject{
 		query: deleteValidatorRewardsInEpochQuery,
 		table: valRewardsTable,
 		args:  []any{epoch + 1},
@@ -200,7 +320,7 @@ func (s *DBService) DeleteStateMetrics(epoch phase0.Epoch) error {
 	if err != nil {
 		return err
 	}
-	s.Delete(DeletableObject{
+	err = s.Supersede(DeletableObject{
 		query: deleteValidatorRewardsInEpochQuery,
 		table: valRewardsTable,
 		args:  []any{epoch},
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	streamEpochsQuery = `
+		SELECT *
+		FROM %s
+		WHERE %s >= $1 AND %s < $2
+		ORDER BY %s`
+
+	// duplicated keys of a ReplacingMergeTree are only merged in the background, FINAL returns one row per key
+	streamFinalEpochsQuery = `
+		SELECT *
+		FROM %s FINAL
+		WHERE %s >= $1 AND %s < $2
+		ORDER BY %s`
+)
+
+// Streamable tables that are a plain MergeTree in ClickHouse, the rest are ReplacingMergeTree
+var mergeTreeTables = map[string]bool{
+	valLastStatusTable: true,
+}
+
+// Column of a streamed table, Type is the ClickHouse type of the migrations (UInt64, String, Array(String)...)
+type Column struct {
+	Name string
+	Type string
+}
+
+// Column that places the rows of a table in an epoch
+type epochColumn struct {
+	name          string
+	slotsPerValue uint64 // 1 for epoch columns, SlotsPerEpoch for slot columns
+}
+
+// Tables that can be streamed by epoch range
+var epochColumns = map[string]epochColumn{
+	attestationsTable:          {"f_epoch", 1},
+	blobsTable:                 {"f_slot", spec.SlotsPerEpoch},
+	blobEventsTable:            {"f_slot", spec.SlotsPerEpoch},
+	blocksTable:                {"f_epoch", 1},
+	blockRewardsTable:          {"f_slot", spec.SlotsPerEpoch},
+	blsToExecutionChangesTable: {"f_epoch", 1},
+	depositsTable:              {"f_epoch", 1},
+	epochFinalityTable:         {"f_epoch", 1},
+	epochsTable:                {"f_epoch", 1},
+	finalizedTable:             {"f_epoch", 1},
+	headEventsTable:            {"f_slot", spec.SlotsPerEpoch},
+	orphansTable:               {"f_epoch", 1},
+	proposerDutiesTable:        {"f_proposer_slot", spec.SlotsPerEpoch},
+	reorgsTable:                {"f_slot", spec.SlotsPerEpoch},
+	rewardsAuditTable:          {"f_epoch", 1},
+	slashingsTable:             {"f_epoch", 1},
+	syncParticipationTable:     {"f_epoch", 1},
+	transactionsTable:          {"f_slot", spec.SlotsPerEpoch},
+	valAttestationsTable:       {"f_epoch", 1},
+	valEventsTable:             {"f_epoch", 1},
+	valLastStatusTable:         {"f_epoch", 1},
+	valRewardsTable:            {"f_epoch", 1},
+	valStatusHistoryTable:      {"f_valid_from", 1},
+	voluntaryExitsTable:        {"f_epoch", 1},
+	withdrawalsTable:           {"f_slot", spec.SlotsPerEpoch},
+}
+
+// Tables that can be streamed with StreamEpochs
+func StreamableTables() []string {
+	tables := make([]string, 0, len(epochColumns))
+	for table := range epochColumns {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// Calls fn with every row of the table between fromEpoch and toEpoch (both included), ordered by epoch
+// Rows are read one by one from the database, they are never held in memory all together
+func (p *DBService) StreamEpochs(
+	table string,
+	fromEpoch phase0.Epoch,
+	toEpoch phase0.Epoch,
+	fn func(epoch phase0.Epoch, columns []Column, row []any) error) error {
+
+	column, ok := epochColumns[table]
+	if !ok {
+		return fmt.Errorf("table %s cannot be streamed by epoch", table)
+	}
+
+	// what was buffered belongs to the range too, a read only connection has nothing buffered
+	if !p.readOnly {
+		p.Flush()
+	}
+
+	columnIdx := -1
+	query := fmt.Sprintf(streamEpochsQuery, table, column.name, column.name, column.name)
+	if p.versioned(table) {
+		query = fmt.Sprintf(streamCanonicalEpochsQuery, table, column.name, column.name, column.name)
+	} else if p.backend.name() == clickhouseScheme && !mergeTreeTables[table] {
+		query = fmt.Sprintf(streamFinalEpochsQuery, table, column.name, column.name, column.name)
+	}
+	from := uint64(fromEpoch) * column.slotsPerValue
+	to := (uint64(toEpoch) + 1) * column.slotsPerValue
+
+	// the lock is only held until the query returns its first row, other reads are not blocked by a long stream
+	p.highMu.Lock()
+	locked := true
+	unlock := func() {
+		if locked {
+			locked = false
+			p.highMu.Unlock()
+		}
+	}
+	defer unlock()
+
+	return p.backend.stream(p.ctx, query, []any{from, to}, func(columns []Column, row []any) error {
+		unlock()
+		if columnIdx < 0 {
+			for i, c := range columns {
+				if c.Name == column.name {
+					columnIdx = i
+				}
+			}
+			if columnIdx < 0 {
+				return fmt.Errorf("column %s not found in %s", column.name, table)
+			}
+		}
+
+		value, ok := unsignedValue(row[columnIdx])
+		if !ok {
+			// backends without unsigned types
+			signed, isInt := row[columnIdx].(int64)
+			if !isInt {
+				return fmt.Errorf("column %s of %s is not an integer", column.name, table)
+			}
+			value = uint64(signed)
+		}
+		return fn(phase0.Epoch(value/column.slotsPerValue), columns, row)
+	})
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+func TestStreamEpochsDoesNotBlockReads(t *testing.T) {
+	store := newTestMemoryStore(t, "")
+	defer store.Finish()
+
+	var epochs []spec.Epoch
+	for epoch := phase0.Epoch(1); epoch <= 3; epoch++ {
+		epochs = append(epochs, spec.Epoch{Epoch: epoch, Slot: phase0.Slot(epoch) * spec.SlotsPerEpoch})
+	}
+	store.PersistEpochs(epochs)
+
+	// other reads go on while the rows are streamed
+	streamed := 0
+	done := runAsync(func() {
+		err := store.StreamEpochs(epochsTable, 1, 3, func(epoch phase0.Epoch, columns []Column, row []any) error {
+			streamed++
+			_, err := store.RetrieveLastEpoch()
+			return err
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	if !returned(done) {
+		t.Fatal("reads were blocked by the stream")
+	}
+	if streamed != 3 {
+		t.Errorf("expected 3 streamed epochs, got %d", streamed)
+	}
+}
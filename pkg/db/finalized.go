@@ -49,7 +49,7 @@ func (p *DBService) PersistFinalized(data []api.FinalizedCheckpointEvent) error
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting checkpoint: %s", err.Error())
 	}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+var (
+	finalizedColumn = "f_finalized" // the roots of the slot or epoch were checked once finalized
+
+	finalizeRowsQuery = `
+		UPDATE %s
+		SET f_finalized = true
+		WHERE %s >= $1 AND %s < $2`
+)
+
+// Tables with the f_finalized column, rows are placed in an epoch through their epochColumns
+var finalizableTables = map[string]bool{
+	blocksTable:       true,
+	attestationsTable: true,
+	transactionsTable: true,
+	withdrawalsTable:  true,
+	epochsTable:       true,
+}
+
+// Last epoch whose roots were checked once finalized
+type finalizedEpoch struct {
+	epoch     phase0.Epoch
+	set       bool         // false until the first epoch is marked
+	seeded    phase0.Epoch // epochs up to it were finalized before their rows were persisted, see SetFinalized
+	seededSet bool
+	mu        sync.RWMutex // held while persisting, so no batch is written with an older epoch after marking
+}
+
+// Adds the finalized column to the insert, rows of finalized epochs are written already finalized
+func finalizedInsert(query string, table string, input proto.Input, epoch phase0.Epoch, set bool) (string, proto.Input) {
+	column := epochColumns[table]
+
+	var key proto.ColInput
+	for _, col := range input {
+		if col.Name == column.name {
+			key = col.Data
+		}
+	}
+
+	var f_finalized proto.ColBool
+	for i := 0; i < inputRows(input); i++ {
+		finalized := false
+		if set && key != nil {
+			value, err := columnValue(key, i)
+			if err == nil {
+				slotOrEpoch, _ := unsignedValue(value)
+				finalized = phase0.Epoch(slotOrEpoch/column.slotsPerValue) <= epoch
+			}
+		}
+		f_finalized.Append(finalized)
+	}
+	return insertColumn(query, input, proto.InputColumn{Name: finalizedColumn, Data: f_finalized})
+}
+
+// Marks the rows of the epoch as finalized, to be called once the roots of the epoch were checked.
+// Rows of the epoch or earlier ones that are persisted afterwards are written already finalized.
+func (p *DBService) MarkFinalized(epoch phase0.Epoch) error {
+	p.finalized.mu.Lock()
+	if !p.finalized.set || epoch > p.finalized.epoch {
+		p.finalized.epoch = epoch
+		p.finalized.set = true
+	}
+	seeded := p.finalized.seededSet && epoch <= p.finalized.seeded
+	p.finalized.mu.Unlock()
+
+	if seeded {
+		return nil // its rows were written finalized, nothing to rewrite
+	}
+
+	// batches queued before marking were written as not finalized
+	p.waitWrites()
+
+	tables := make([]string, 0, len(finalizableTables))
+	for table := range finalizableTables {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	startTime := time.Now()
+	for _, table := range tables {
+		column := epochColumns[table]
+		from := uint64(epoch) * column.slotsPerValue
+		to := (uint64(epoch) + 1) * column.slotsPerValue
+
+		query := fmt.Sprintf(finalizeRowsQuery, table, column.name, column.name)
+		if p.versioned(table) {
+			// versioned tables are not mutated, the finalized copies replace the rows
+			query = replaceCanonicalRows(table, "true AS "+finalizedColumn, p.versions.next(),
+				fmt.Sprintf("NOT f_finalized AND %s >= $1 AND %s < $2", column.name, column.name))
+		}
+
+		p.highMu.Lock()
+		err := p.backend.exec(p.ctx, query, from, to)
+		p.highMu.Unlock()
+		if err != nil {
+			return fmt.Errorf("could not mark %s finalized at epoch %d: %s", table, epoch, err)
+		}
+	}
+	log.Debugf("rows of epoch %d marked finalized in %f seconds", epoch, time.Since(startTime).Seconds())
+	return nil
+}
+
+// Rows up to the epoch are written finalized from now on, without rewriting the rows already persisted.
+// To be called with the finalized checkpoint of the node before persisting the epochs it finalizes,
+// so that backfilling finalized epochs does not need to mark them afterwards.
+func (p *DBService) SetFinalized(epoch phase0.Epoch) {
+	p.finalized.mu.Lock()
+	defer p.finalized.mu.Unlock()
+
+	if !p.finalized.set || epoch > p.finalized.epoch {
+		p.finalized.epoch = epoch
+		p.finalized.set = true
+	}
+	if !p.finalized.seededSet || epoch > p.finalized.seeded {
+		p.finalized.seeded = epoch
+		p.finalized.seededSet = true
+	}
+}
@@ -72,7 +72,7 @@ func (p *DBService) PersistHeadEvents(data []HeadEvent) error {
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting head events: %s", err.Error())
 	}
@@ -13,24 +13,6 @@ import (
 	"github.com/migalabs/goteth/pkg/utils"
 )
 
-func (s *DBService) ConnectHighLevel() error {
-	opts := ParseChUrlIntoOptionsHighLevel(s.connectionUrl)
-	conn, err := clickhouse.Open(&opts)
-	if err != nil {
-		return err
-	}
-
-	s.highLevelClient = conn
-	erro := conn.Ping(context.Background())
-	if erro != nil {
-		return erro
-	}
-
-	log.Info("high level client is connected")
-	return nil
-
-}
-
 func ParseChUrlIntoOptionsHighLevel(url string) clickhouse.Options {
 	var user string
 	var password string
@@ -106,21 +88,49 @@ func (p *DBService) Delete(obj DeletableObject) error {
 	var err error
 	startTime := time.Now()
 
+	err = p.persistPending(obj.table)
+	if err != nil {
+		return err
+	}
+
 	p.highMu.Lock()
-	err = p.highLevelClient.Exec(p.ctx, obj.Query(), obj.Args()...)
+	err = p.backend.exec(p.ctx, obj.Query(), obj.Args()...)
 	p.highMu.Unlock()
 
 	if err == nil {
 		log.Infof("query: %s finished in %f seconds", obj.Query(), time.Since(startTime).Seconds())
+		p.publishDelete(obj)
 	}
 
 	return err
 }
 
+// Writes the buffered and spilled rows, they could belong to what is being deleted.
+// Fails while spilled rows of the table remain: replayed after the delete, they would come back as current rows
+func (p *DBService) persistPending(table string) error {
+	p.Flush()
+	err := p.replaySpilled()
+	if err == nil {
+		return nil
+	}
+
+	pending, spillErr := p.spilledTable(table)
+	if spillErr != nil {
+		return spillErr
+	}
+	if pending {
+		return fmt.Errorf("spilled rows of %s could not be replayed before deleting: %s", table, err)
+	}
+	log.Warnf("spilled rows could not be replayed before deleting from %s: %s", table, err)
+	return nil
+}
+
 func (p *DBService) highSelect(query string, dest interface{}) error {
 	startTime := time.Now()
+
+	// buffered rows are not flushed, reads can lag behind them until the next batch
 	p.highMu.Lock()
-	err := p.highLevelClient.Select(p.ctx, dest, query)
+	err := p.backend.query(p.ctx, dest, query)
 	p.highMu.Unlock()
 
 	if err == nil {
@@ -1,40 +1,14 @@
 package db
 
 import (
-	"context"
 	"crypto/tls"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/ClickHouse/ch-go"
 	"github.com/ClickHouse/ch-go/proto"
 )
 
-func (s *DBService) ConnectLowLevel(retry bool) error {
-	ctx := context.Background()
-
-	opts, migrationUrl := ParseChUrlIntoOptionsLowLevel(s.connectionUrl)
-
-	lowLevelConn, err := ch.Dial(ctx, opts)
-	if err == nil {
-		s.lowLevelClient = lowLevelConn
-		s.migrationUrl = migrationUrl
-		log.Info("low level client is connected")
-		// err = s.makeMigrations(migrationUrl)
-	} else {
-		if retry {
-			// database may be in idle state, wait 30 seconds and retry another time
-			log.Warning("database could be idle, service will retry to connect another time in 30 seconds ...")
-			time.Sleep(30 * time.Second)
-			return s.ConnectLowLevel(false)
-		}
-	}
-
-	return err
-
-}
-
 func ParseChUrlIntoOptionsLowLevel(url string) (ch.Options, string) {
 	var user string
 	var password string
@@ -77,29 +51,46 @@ func ParseChUrlIntoOptionsLowLevel(url string) (ch.Options, string) {
 	return options, migrationDatabaseUrl
 }
 
+// Queues the rows to be inserted by one of the db writers, it blocks while the queue is full.
+// Once the writers are closed the rows are spilled, to be replayed on the next run
 func (p *DBService) Persist(
 	query string,
 	table string,
 	input proto.Input,
 	rows int) error {
 
-	startTime := time.Now()
+	p.writeMu.RLock()
+	defer p.writeMu.RUnlock()
 
-	p.lowMu.Lock()
-	err := p.lowLevelClient.Do(p.ctx, ch.Query{
-		Body:  query,
-		Input: input,
-	})
-	p.lowMu.Unlock()
-	elapsedTime := time.Since(startTime)
+	// some inputs flatten the objects they receive (e.g. attestations per block),
+	// so the batch size is the one of the columns
+	if len(input) > 0 {
+		rows = input[0].Data.Rows()
+	}
+	if p.versioned(table) {
+		query, input = versionedInsert(query, input, p.versions.next())
+	}
 
-	if err == nil {
-		log.Debugf("table %s persisted %d rows in %fs", table, rows, elapsedTime.Seconds())
+	p.finalized.mu.RLock()
+	defer p.finalized.mu.RUnlock()
+	if finalizableTables[table] {
+		query, input = finalizedInsert(query, table, input, p.finalized.epoch, p.finalized.set)
+	}
 
-		p.metricsMu.Lock()
-		p.monitorMetrics[table].addNewPersist(rows, elapsedTime)
-		p.metricsMu.Unlock()
+	task := writeTask{
+		query: query,
+		table: table,
+		input: input,
+		rows:  rows,
 	}
 
-	return err
+	if p.writersClosed {
+		p.spill(task)
+		return fmt.Errorf("db writers are closed, %d rows to %s were not persisted", rows, table)
+	}
+
+	p.addPending()
+	p.writeQueue <- task
+
+	return nil
 }
@@ -0,0 +1,352 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	memoryScheme = "memory"
+
+	// only the shapes of the selects issued by the persisters are understood, there is no SQL engine
+	memoryStreamRegex = regexp.MustCompile(`(?s)^\s*SELECT \*\s+FROM (\w+)\s+WHERE (.+?)\s+ORDER BY (\w+)\s*;?\s*$`)
+	memorySelectRegex = regexp.MustCompile(`(?s)^\s*SELECT (\w+)\s+FROM (\w+)(?:\s+ORDER BY (\w+) DESC\s+LIMIT 1)?\s*;?\s*$`)
+	memoryUpdateRegex = regexp.MustCompile(`(?s)^\s*UPDATE (\w+)\s+SET (\w+) = true\s+WHERE (.+?)\s*;?\s*$`)
+)
+
+// Keeps every table in memory, the url is memory://<dump dir>
+// When a dump directory is given, each table is written as <table>.jsonl when the service finishes
+type memoryBackend struct {
+	dumpDir string
+
+	tables  map[string][]Row
+	columns map[string][]Column // in the order they were inserted
+	mu      sync.RWMutex
+}
+
+func newMemoryBackend(url string) *memoryBackend {
+	_, dumpDir, _ := strings.Cut(url, "://")
+	return &memoryBackend{
+		dumpDir: dumpDir,
+		tables:  make(map[string][]Row),
+		columns: make(map[string][]Column),
+	}
+}
+
+func (b *memoryBackend) name() string {
+	return memoryScheme
+}
+
+func (b *memoryBackend) connect(ctx context.Context) error {
+	if b.dumpDir == "" {
+		return nil
+	}
+	return os.MkdirAll(b.dumpDir, 0755)
+}
+
+func (b *memoryBackend) migrate() error {
+	return nil
+}
+
+func (b *memoryBackend) newWriter(ctx context.Context) (dbWriter, error) {
+	return &memoryWriter{backend: b}, nil
+}
+
+func (b *memoryBackend) exec(ctx context.Context, query string, args ...any) error {
+	if strings.Contains(query, "INSERT INTO") && strings.Contains(query, "SELECT") {
+		// aggregations over other tables (pool summaries), nothing to derive them from in memory
+		log.Debugf("memory backend skips query: %s", query)
+		return nil
+	}
+
+	if match := memoryUpdateRegex.FindStringSubmatch(query); match != nil {
+		conditions, err := parseConditions(match[3], args)
+		if err != nil {
+			return fmt.Errorf("%s: %s", err, query)
+		}
+		return b.update(match[1], match[2], conditions)
+	}
+
+	table, conditions, err := parseDelete(query, args)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.tables[table]) == 0 {
+		return nil
+	}
+
+	kept := make([]Row, 0, len(b.tables[table]))
+	for _, row := range b.tables[table] {
+		matches, err := matchesConditions(table, row, conditions)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			kept = append(kept, row)
+		}
+	}
+	b.tables[table] = kept
+	return nil
+}
+
+// Sets the flag column of the rows that match the conditions
+func (b *memoryBackend) update(table string, column string, conditions []Condition) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, row := range b.tables[table] {
+		matches, err := matchesConditions(table, row, conditions)
+		if err != nil {
+			return err
+		}
+		if matches {
+			row[column] = true
+		}
+	}
+	return nil
+}
+
+func matchesConditions(table string, row Row, conditions []Condition) (bool, error) {
+	matches := true
+	for _, condition := range conditions {
+		value, ok := unsignedValue(row[condition.Column])
+		if !ok {
+			return false, fmt.Errorf("column %s of %s is not unsigned", condition.Column, table)
+		}
+		matches = matches && condition.Matches(value)
+	}
+	return matches, nil
+}
+
+// Fills the single field of the dest structs with the column of every row, or with the highest one when ordered
+func (b *memoryBackend) query(ctx context.Context, dest any, query string, args ...any) error {
+	match := memorySelectRegex.FindStringSubmatch(query)
+	if match == nil {
+		return fmt.Errorf("query not supported by the memory backend: %s", query)
+	}
+	column, table, orderBy := match[1], match[2], match[3]
+
+	slice := reflect.ValueOf(dest)
+	if slice.Kind() != reflect.Pointer || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("query destination must be a pointer to a slice, got %T", dest)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+	if elemType.Kind() != reflect.Struct || elemType.NumField() != 1 {
+		return fmt.Errorf("query destination must be a slice of single field structs, got %s", elemType)
+	}
+
+	b.mu.RLock()
+	rows := b.tables[table]
+	if orderBy != "" && len(rows) > 0 {
+		last := rows[0]
+		for _, row := range rows[1:] {
+			value, _ := unsignedValue(row[orderBy])
+			lastValue, _ := unsignedValue(last[orderBy])
+			if value > lastValue {
+				last = row
+			}
+		}
+		rows = []Row{last}
+	}
+	b.mu.RUnlock()
+
+	for _, row := range rows {
+		value := reflect.ValueOf(row[column])
+		elem := reflect.New(elemType).Elem()
+		if !value.IsValid() || !value.CanConvert(elem.Field(0).Type()) {
+			return fmt.Errorf("cannot read column %s of %s into %s", column, table, elem.Field(0).Type())
+		}
+		elem.Field(0).Set(value.Convert(elem.Field(0).Type()))
+		slice.Set(reflect.Append(slice, elem))
+	}
+	return nil
+}
+
+// Calls fn with the rows that match the conditions, sorted by the given column
+func (b *memoryBackend) stream(ctx context.Context, query string, args []any, fn func(columns []Column, row []any) error) error {
+	match := memoryStreamRegex.FindStringSubmatch(query)
+	if match == nil {
+		return fmt.Errorf("query not supported by the memory backend: %s", query)
+	}
+	table, orderBy := match[1], match[3]
+
+	conditions, err := parseConditions(match[2], args)
+	if err != nil {
+		return fmt.Errorf("%s: %s", err, query)
+	}
+
+	b.mu.RLock()
+	columns := b.columns[table]
+	var selected []Row
+	for _, row := range b.tables[table] {
+		matches := true
+		for _, condition := range conditions {
+			value, _ := unsignedValue(row[condition.Column])
+			matches = matches && condition.Matches(value)
+		}
+		if matches {
+			selected = append(selected, row)
+		}
+	}
+	b.mu.RUnlock()
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		vi, _ := unsignedValue(selected[i][orderBy])
+		vj, _ := unsignedValue(selected[j][orderBy])
+		return vi < vj
+	})
+
+	for _, row := range selected {
+		values := make([]any, len(columns))
+		for i, column := range columns {
+			values[i] = row[column.Name]
+		}
+		err = fn(columns, values)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *memoryBackend) close() {
+	if b.dumpDir == "" {
+		return
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for table, rows := range b.tables {
+		err := dumpTable(filepath.Join(b.dumpDir, table+".jsonl"), rows)
+		if err != nil {
+			log.Errorf("could not dump %s: %s", table, err)
+			continue
+		}
+		log.Infof("dumped %d rows of %s to %s", len(rows), table, b.dumpDir)
+	}
+}
+
+// Copy of the rows of the table, in insertion order
+func (b *memoryBackend) rows(table string) []Row {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]Row(nil), b.tables[table]...)
+}
+
+func (b *memoryBackend) tableNames() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	names := make([]string, 0, len(b.tables))
+	for table := range b.tables {
+		names = append(names, table)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Writes one JSON object per row
+func dumpTable(path string, rows []Row) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, row := range rows {
+		err = enc.Encode(row)
+		if err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func unsignedValue(value any) (uint64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), true
+	default:
+		return 0, false
+	}
+}
+
+// Appends the batch rows to the table
+type memoryWriter struct {
+	backend *memoryBackend
+}
+
+func (w *memoryWriter) insert(ctx context.Context, task writeTask) error {
+	rows, err := taskRows(task)
+	if err != nil {
+		return err
+	}
+
+	w.backend.mu.Lock()
+	if _, ok := w.backend.columns[task.table]; !ok {
+		columns := make([]Column, len(task.input))
+		for i, col := range task.input {
+			columns[i] = Column{Name: col.Name, Type: string(col.Data.Type())}
+		}
+		w.backend.columns[task.table] = columns
+	}
+	w.backend.tables[task.table] = append(w.backend.tables[task.table], rows...)
+	w.backend.mu.Unlock()
+	return nil
+}
+
+func (w *memoryWriter) isClosed() bool {
+	return false
+}
+
+func (w *memoryWriter) close() {}
+
+// Store kept in memory, for tests and for indexing a few epochs without a database
+type MemoryStore struct {
+	*DBService
+	mem *memoryBackend
+}
+
+// Creates a store in memory, the tables are dumped as JSON lines into dumpDir when it finishes, if given
+func NewMemoryStore(ctx context.Context, dumpDir string, options ...DBServiceOption) (*MemoryStore, error) {
+	service, err := New(ctx, fmt.Sprintf("%s://%s", memoryScheme, dumpDir), options...)
+	if err != nil {
+		return nil, err
+	}
+	backend, ok := service.backend.(*memoryBackend)
+	if !ok {
+		return nil, fmt.Errorf("expected a %s backend, got %s", memoryScheme, service.backend.name())
+	}
+	return &MemoryStore{
+		DBService: service,
+		mem:       backend,
+	}, nil
+}
+
+// Rows persisted so far into the table, buffered rows are flushed first
+func (m *MemoryStore) Rows(table string) []Row {
+	m.Flush()
+	return m.mem.rows(table)
+}
+
+// Tables that received at least one row
+func (m *MemoryStore) Tables() []string {
+	m.Flush()
+	return m.mem.tableNames()
+}
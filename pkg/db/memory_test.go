@@ -0,0 +1,256 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/spec"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+func newTestMemoryStore(t *testing.T, dumpDir string) *MemoryStore {
+	store, err := NewMemoryStore(context.Background(), dumpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func testBlock(slot phase0.Slot) spec.AgnosticBlock {
+	return spec.AgnosticBlock{
+		Slot:          slot,
+		ProposerIndex: phase0.ValidatorIndex(slot),
+		Proposed:      true,
+		SyncAggregate: &altair.SyncAggregate{SyncCommitteeBits: bitfield.NewBitvector512()},
+	}
+}
+
+func slotsOf(rows []Row) []uint64 {
+	slots := make([]uint64, len(rows))
+	for i, row := range rows {
+		slots[i] = row["f_slot"].(uint64)
+	}
+	return slots
+}
+
+func TestMemoryStoreBlocks(t *testing.T) {
+	store := newTestMemoryStore(t, "")
+	defer store.Finish()
+
+	err := store.PersistBlocks([]spec.AgnosticBlock{testBlock(32), testBlock(48), testBlock(33)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// reads do not flush the buffered rows
+	lastSlot, err := store.RetrieveLastSlot()
+	if err != nil || lastSlot != 0 {
+		t.Errorf("expected no blocks before flushing, got last slot %d (%v)", lastSlot, err)
+	}
+
+	rows := store.Rows(blocksTable)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(rows))
+	}
+	if rows[0]["f_epoch"] != uint64(2) || rows[0]["f_proposed"] != true {
+		t.Errorf("unexpected block row %v", rows[0])
+	}
+
+	lastSlot, err = store.RetrieveLastSlot()
+	if err != nil || lastSlot != 48 {
+		t.Errorf("expected last slot 48, got %d (%v)", lastSlot, err)
+	}
+
+	err = store.DeleteBlockMetrics(33)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slots := slotsOf(store.Rows(blocksTable)); len(slots) != 2 || slots[0] != 32 || slots[1] != 48 {
+		t.Errorf("expected slots 32 and 48 after the delete, got %v", slots)
+	}
+}
+
+func TestMemoryStoreStateMetrics(t *testing.T) {
+	store := newTestMemoryStore(t, "")
+	defer store.Finish()
+
+	var epochs []spec.Epoch
+	var duties []spec.ProposerDuty
+	for epoch := phase0.Epoch(1); epoch <= 4; epoch++ {
+		epochs = append(epochs, spec.Epoch{Epoch: epoch, Slot: phase0.Slot(epoch) * spec.SlotsPerEpoch})
+		duties = append(duties, spec.ProposerDuty{ProposerSlot: phase0.Slot(epoch)*spec.SlotsPerEpoch + 1})
+	}
+	store.PersistEpochs(epochs)
+	store.PersistDuties(duties)
+	store.Flush()
+
+	lastEpoch, err := store.RetrieveLastEpoch()
+	if err != nil || lastEpoch != 4 {
+		t.Errorf("expected last epoch 4, got %d (%v)", lastEpoch, err)
+	}
+
+	// the epoch metrics of the previous epoch are written with the deleted state too
+	err = store.DeleteStateMetrics(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := store.Rows(epochsTable)
+	if len(rows) != 2 || rows[0]["f_epoch"] != uint64(1) || rows[1]["f_epoch"] != uint64(4) {
+		t.Errorf("expected epochs 1 and 4 after the delete, got %v", rows)
+	}
+	if rows := store.Rows(proposerDutiesTable); len(rows) != 3 {
+		t.Errorf("expected the duties of epoch 3 to be deleted, got %v", rows)
+	}
+}
+
+func TestMemoryStoreValidatorStatuses(t *testing.T) {
+	store := newTestMemoryStore(t, "")
+	defer store.Finish()
+
+	active := spec.ValidatorStatusHistory{
+		ValIdx:                0,
+		ValidFrom:             5,
+		Status:                spec.ACTIVE_STATUS,
+		EffectiveBalance:      32_000_000_000,
+		WithdrawalCredentials: []byte{0x01, 0x02},
+	}
+	exited := active
+	exited.ValidFrom = 8
+	exited.Status = spec.EXIT_STATUS
+	other := active
+	other.ValIdx = 1
+	store.PersistValidatorStatusHistory([]spec.ValidatorStatusHistory{active, other, exited})
+
+	// the last status of each validator before the epoch, rows of the epoch are left out
+	statuses, err := store.RetrieveValidatorStatuses(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || !statuses[0].Equal(active) || !statuses[1].Equal(other) {
+		t.Errorf("expected the statuses valid from epoch 5, got %v", statuses)
+	}
+
+	statuses, err = store.RetrieveValidatorStatuses(9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !statuses[0].Equal(exited) || statuses[0].ValidFrom != 8 {
+		t.Errorf("expected the exit of validator 0, got %v", statuses[0])
+	}
+}
+
+func TestMemoryStoreFinalized(t *testing.T) {
+	store := newTestMemoryStore(t, "")
+	defer store.Finish()
+
+	err := store.PersistBlocks([]spec.AgnosticBlock{testBlock(16), testBlock(32), testBlock(48)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.PersistEpochs([]spec.Epoch{{Epoch: 1, Slot: 16}, {Epoch: 2, Slot: 32}})
+	store.Flush()
+
+	err = store.MarkFinalized(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// only the marked epoch, earlier ones are marked by their own call
+	for _, table := range []string{blocksTable, epochsTable} {
+		for _, row := range store.Rows(table) {
+			expected := row["f_epoch"] == uint64(2)
+			if row[finalizedColumn] != expected {
+				t.Errorf("%s epoch %v: expected finalized %v, got %v", table, row["f_epoch"], expected, row[finalizedColumn])
+			}
+		}
+	}
+
+	// rewritten rows of finalized epochs are written already finalized
+	err = store.DeleteBlockMetrics(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.PersistBlocks([]spec.AgnosticBlock{testBlock(16), testBlock(64)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range store.Rows(blocksTable) {
+		expected := row["f_slot"] == uint64(16) || row["f_slot"] == uint64(32)
+		if row[finalizedColumn] != expected {
+			t.Errorf("slot %v: expected finalized %v, got %v", row["f_slot"], expected, row[finalizedColumn])
+		}
+	}
+}
+
+func TestMemoryStoreSetFinalized(t *testing.T) {
+	store := newTestMemoryStore(t, "")
+	defer store.Finish()
+
+	// backfilling epochs the node already finalized
+	store.SetFinalized(2)
+	err := store.PersistBlocks([]spec.AgnosticBlock{testBlock(16), testBlock(32), testBlock(48)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Flush()
+	for _, row := range store.Rows(blocksTable) {
+		expected := row["f_slot"] != uint64(48)
+		if row[finalizedColumn] != expected {
+			t.Errorf("slot %v: expected finalized %v, got %v", row["f_slot"], expected, row[finalizedColumn])
+		}
+	}
+
+	// epochs after the seeded one are still rewritten once marked
+	for _, epoch := range []phase0.Epoch{2, 3} {
+		err = store.MarkFinalized(epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, row := range store.Rows(blocksTable) {
+		if row[finalizedColumn] != true {
+			t.Errorf("slot %v: expected finalized, got %v", row["f_slot"], row[finalizedColumn])
+		}
+	}
+}
+
+func TestMemoryStoreDump(t *testing.T) {
+	dumpDir := filepath.Join(t.TempDir(), "dump")
+	store := newTestMemoryStore(t, dumpDir)
+
+	store.PersistBlocks([]spec.AgnosticBlock{testBlock(32), testBlock(33)})
+	store.Finish()
+
+	f, err := os.Open(filepath.Join(dumpDir, blocksTable+".jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var slots []uint64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var row struct {
+			Slot uint64 `json:"f_slot"`
+		}
+		err = json.Unmarshal(scanner.Bytes(), &row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		slots = append(slots, row.Slot)
+	}
+	if len(slots) != 2 || slots[0] != 32 || slots[1] != 33 {
+		t.Errorf("expected slots 32 and 33 in the dump, got %v", slots)
+	}
+}
@@ -11,6 +11,9 @@ type DBMetrics struct {
 	ValidatorRewards bool
 	APIRewards       bool
 	Transactions     bool
+	Attestations     bool
+	SyncCommittee    bool
+	RewardsAudit     bool
 }
 
 func NewMetrics(input string) (DBMetrics, error) {
@@ -28,8 +31,21 @@ func NewMetrics(input string) (DBMetrics, error) {
 			dbMetrics.ValidatorRewards = true
 			dbMetrics.Epoch = true
 			dbMetrics.Block = true
+		case "rewards_audit":
+			dbMetrics.RewardsAudit = true
+			dbMetrics.ValidatorRewards = true
+			dbMetrics.Epoch = true
+			dbMetrics.Block = true
 		case "api_rewards":
 			dbMetrics.APIRewards = true
+		case "attestations":
+			dbMetrics.Attestations = true
+			dbMetrics.Epoch = true
+			dbMetrics.Block = true
+		case "sync_committee":
+			dbMetrics.SyncCommittee = true
+			dbMetrics.Epoch = true
+			dbMetrics.Block = true
 		case "transactions":
 			dbMetrics.Transactions = true
 			dbMetrics.Block = true
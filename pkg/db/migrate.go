@@ -7,12 +7,12 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
-func (s *DBService) makeMigrations() error {
+func runMigrations(sourceUrl string, databaseUrl string) error {
 	log.Info("will try to apply migrations ...")
 
 	m, err := migrate.New(
-		"file://pkg/db/migrations",
-		s.migrationUrl)
+		sourceUrl,
+		databaseUrl)
 
 	if err != nil {
 		log.Errorf("could not create DB migrator: %s", err.Error())
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS t_validator_attestations;
\ No newline at end of file
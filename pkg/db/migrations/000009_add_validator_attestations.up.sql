@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS t_validator_attestations(
+	f_val_idx UInt64,
+	f_epoch UInt64,
+	f_att_slot UInt64,
+	f_committee_index UInt64,
+	f_inclusion_slot UInt64,
+	f_inclusion_delay UInt8)
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_epoch, f_val_idx);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS t_sync_committee_participation;
\ No newline at end of file
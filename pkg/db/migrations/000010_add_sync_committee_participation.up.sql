@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS t_sync_committee_participation(
+	f_val_idx UInt64,
+	f_epoch UInt64,
+	f_missed_slots UInt64,
+	f_num_missed UInt8)
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_epoch, f_val_idx);
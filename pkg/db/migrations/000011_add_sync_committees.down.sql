@@ -0,0 +1 @@
+DROP TABLE IF EXISTS t_sync_committees;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS t_sync_committees(
+	f_period UInt64,
+	f_position UInt16,
+	f_val_idx UInt64,
+	f_public_key TEXT)
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_period, f_position);
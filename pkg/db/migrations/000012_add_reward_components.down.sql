@@ -0,0 +1,7 @@
+ALTER TABLE t_validator_rewards_summary DROP COLUMN f_source_reward;
+ALTER TABLE t_validator_rewards_summary DROP COLUMN f_target_reward;
+ALTER TABLE t_validator_rewards_summary DROP COLUMN f_head_reward;
+ALTER TABLE t_validator_rewards_summary DROP COLUMN f_sync_reward;
+ALTER TABLE t_validator_rewards_summary DROP COLUMN f_proposer_reward;
+ALTER TABLE t_validator_rewards_summary DROP COLUMN f_slashing_reward;
+ALTER TABLE t_validator_rewards_summary DROP COLUMN f_inactivity_reward;
@@ -0,0 +1,7 @@
+ALTER TABLE t_validator_rewards_summary ADD COLUMN f_source_reward Int64 DEFAULT 0;
+ALTER TABLE t_validator_rewards_summary ADD COLUMN f_target_reward Int64 DEFAULT 0;
+ALTER TABLE t_validator_rewards_summary ADD COLUMN f_head_reward Int64 DEFAULT 0;
+ALTER TABLE t_validator_rewards_summary ADD COLUMN f_sync_reward Int64 DEFAULT 0;
+ALTER TABLE t_validator_rewards_summary ADD COLUMN f_proposer_reward Int64 DEFAULT 0;
+ALTER TABLE t_validator_rewards_summary ADD COLUMN f_slashing_reward Int64 DEFAULT 0;
+ALTER TABLE t_validator_rewards_summary ADD COLUMN f_inactivity_reward Int64 DEFAULT 0;
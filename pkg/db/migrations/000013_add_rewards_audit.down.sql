@@ -0,0 +1 @@
+DROP TABLE IF EXISTS t_rewards_audit;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS t_rewards_audit(
+	f_val_idx UInt64,
+	f_epoch UInt64,
+	f_component TEXT,
+	f_api_reward Int64,
+	f_computed_reward Int64)
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_epoch, f_val_idx, f_component);
@@ -0,0 +1,3 @@
+ALTER TABLE t_epoch_metrics_summary DROP COLUMN f_epochs_since_finality;
+ALTER TABLE t_epoch_metrics_summary DROP COLUMN f_inactivity_leak;
+ALTER TABLE t_validator_rewards_summary DROP COLUMN f_inactivity_score;
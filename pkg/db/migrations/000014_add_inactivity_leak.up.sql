@@ -0,0 +1,3 @@
+ALTER TABLE t_epoch_metrics_summary ADD COLUMN f_epochs_since_finality UInt64 DEFAULT 0;
+ALTER TABLE t_epoch_metrics_summary ADD COLUMN f_inactivity_leak BOOL DEFAULT false;
+ALTER TABLE t_validator_rewards_summary ADD COLUMN f_inactivity_score UInt64 DEFAULT 0;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS t_epoch_finality(
+	f_epoch UInt64,
+	f_slot UInt64,
+	f_justification_bits UInt8,
+	f_prev_justified_epoch UInt64,
+	f_prev_justified_root TEXT,
+	f_current_justified_epoch UInt64,
+	f_current_justified_root TEXT,
+	f_finalized_epoch UInt64,
+	f_finalized_root TEXT,
+	f_epochs_to_finality UInt64)
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_epoch);
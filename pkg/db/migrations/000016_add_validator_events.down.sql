@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS t_validator_events;
+DROP TABLE IF EXISTS t_validator_event_types;
\ No newline at end of file
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS t_validator_events(
+	f_val_idx UInt64,
+	f_epoch UInt64,
+	f_event UInt8)
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_epoch, f_val_idx, f_event);
+
+CREATE TABLE IF NOT EXISTS t_validator_event_types(
+	f_id UInt8 PRIMARY KEY,
+	f_event TEXT)
+	ENGINE = ReplacingMergeTree()
+	ORDER BY f_id;
+
+INSERT INTO t_validator_event_types VALUES(0, 'deposited');
+INSERT INTO t_validator_event_types VALUES(1, 'eligible');
+INSERT INTO t_validator_event_types VALUES(2, 'activated');
+INSERT INTO t_validator_event_types VALUES(3, 'exit_initiated');
+INSERT INTO t_validator_event_types VALUES(4, 'exited');
+INSERT INTO t_validator_event_types VALUES(5, 'slashed');
+INSERT INTO t_validator_event_types VALUES(6, 'withdrawable');
+INSERT INTO t_validator_event_types VALUES(7, 'fully_withdrawn');
+INSERT INTO t_validator_event_types VALUES(8, 'withdrawal_credentials_change');
@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS v_validator_status_history;
+DROP TABLE IF EXISTS t_validator_status_history;
\ No newline at end of file
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS t_validator_status_history(
+	f_val_idx UInt64,
+	f_valid_from UInt64,
+	f_status UInt8,
+	f_effective_balance_eth Float,
+	f_slashed BOOL,
+	f_withdrawal_credentials TEXT)
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_val_idx, f_valid_from);
+
+-- epochs might be processed in any order, so the end of each period is resolved at query time
+CREATE VIEW IF NOT EXISTS v_validator_status_history AS
+	SELECT
+		f_val_idx,
+		f_valid_from,
+		if(f_next_valid_from = 0, 18446744073709551615, f_next_valid_from - 1) AS f_valid_to,
+		f_status,
+		f_effective_balance_eth,
+		f_slashed,
+		f_withdrawal_credentials
+	FROM (
+		SELECT
+			*,
+			leadInFrame(f_valid_from) OVER (
+				PARTITION BY f_val_idx
+				ORDER BY f_valid_from ASC
+				ROWS BETWEEN CURRENT ROW AND 1 FOLLOWING) AS f_next_valid_from
+		FROM t_validator_status_history FINAL
+	);
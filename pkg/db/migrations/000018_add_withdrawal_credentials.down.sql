@@ -0,0 +1,5 @@
+ALTER TABLE t_validator_last_status DROP COLUMN f_withdrawal_credentials;
+ALTER TABLE t_validator_last_status DROP COLUMN f_withdrawal_type;
+ALTER TABLE t_validator_last_status DROP COLUMN f_withdrawal_address;
+
+DROP TABLE IF EXISTS t_bls_to_execution_changes;
@@ -0,0 +1,12 @@
+ALTER TABLE t_validator_last_status ADD COLUMN f_withdrawal_credentials TEXT DEFAULT '';
+ALTER TABLE t_validator_last_status ADD COLUMN f_withdrawal_type UInt8 DEFAULT 0;
+ALTER TABLE t_validator_last_status ADD COLUMN f_withdrawal_address TEXT DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS t_bls_to_execution_changes(
+	f_slot UInt64,
+	f_epoch UInt64,
+	f_val_idx UInt64,
+	f_from_bls_pubkey TEXT,
+	f_to_execution_address TEXT)
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_slot, f_val_idx);
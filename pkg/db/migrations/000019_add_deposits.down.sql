@@ -0,0 +1 @@
+DROP TABLE IF EXISTS t_deposits;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS t_deposits(
+	f_slot UInt64,
+	f_epoch UInt64,
+	f_index UInt64,
+	f_public_key TEXT,
+	f_withdrawal_credentials TEXT,
+	f_amount UInt64,
+	f_val_idx UInt64,
+	f_deposit_type UInt8,
+	f_el_block_number UInt64,
+	f_el_tx_hash TEXT,
+	f_el_sender TEXT)
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_slot, f_index);
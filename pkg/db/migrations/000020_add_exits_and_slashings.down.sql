@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS t_voluntary_exits;
+DROP TABLE IF EXISTS t_slashings;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS t_voluntary_exits(
+	f_val_idx UInt64,
+	f_epoch UInt64,
+	f_slot UInt64)
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_slot, f_val_idx);
+
+CREATE TABLE IF NOT EXISTS t_slashings(
+	f_slashed_validator_idx UInt64,
+	f_slot UInt64,
+	f_epoch UInt64,
+	f_slashing_type UInt8,
+	f_whistleblower_idx UInt64,
+	f_whistleblower_reward UInt64)
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_slot, f_slashed_validator_idx, f_slashing_type);
@@ -0,0 +1,360 @@
+-- t_block_metrics
+DROP VIEW IF EXISTS v_block_metrics;
+
+CREATE TABLE new_t_block_metrics AS t_block_metrics
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_slot);
+
+INSERT INTO new_t_block_metrics
+SELECT * FROM t_block_metrics FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_block_metrics TO t_block_metrics_old;
+RENAME TABLE new_t_block_metrics TO t_block_metrics;
+DROP TABLE t_block_metrics_old;
+
+ALTER TABLE t_block_metrics
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_transactions
+DROP VIEW IF EXISTS v_transactions;
+
+CREATE TABLE new_t_transactions AS t_transactions
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_slot, f_el_block_number, f_hash);
+
+INSERT INTO new_t_transactions
+SELECT * FROM t_transactions FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_transactions TO t_transactions_old;
+RENAME TABLE new_t_transactions TO t_transactions;
+DROP TABLE t_transactions_old;
+
+ALTER TABLE t_transactions
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_withdrawals
+DROP VIEW IF EXISTS v_withdrawals;
+
+CREATE TABLE new_t_withdrawals AS t_withdrawals
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_index);
+
+INSERT INTO new_t_withdrawals
+SELECT * FROM t_withdrawals FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_withdrawals TO t_withdrawals_old;
+RENAME TABLE new_t_withdrawals TO t_withdrawals;
+DROP TABLE t_withdrawals_old;
+
+ALTER TABLE t_withdrawals
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_voluntary_exits
+DROP VIEW IF EXISTS v_voluntary_exits;
+
+CREATE TABLE new_t_voluntary_exits AS t_voluntary_exits
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_slot, f_val_idx);
+
+INSERT INTO new_t_voluntary_exits
+SELECT * FROM t_voluntary_exits FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_voluntary_exits TO t_voluntary_exits_old;
+RENAME TABLE new_t_voluntary_exits TO t_voluntary_exits;
+DROP TABLE t_voluntary_exits_old;
+
+ALTER TABLE t_voluntary_exits
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_bls_to_execution_changes
+DROP VIEW IF EXISTS v_bls_to_execution_changes;
+
+CREATE TABLE new_t_bls_to_execution_changes AS t_bls_to_execution_changes
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_slot, f_val_idx);
+
+INSERT INTO new_t_bls_to_execution_changes
+SELECT * FROM t_bls_to_execution_changes FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_bls_to_execution_changes TO t_bls_to_execution_changes_old;
+RENAME TABLE new_t_bls_to_execution_changes TO t_bls_to_execution_changes;
+DROP TABLE t_bls_to_execution_changes_old;
+
+ALTER TABLE t_bls_to_execution_changes
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_blob_sidecars
+DROP VIEW IF EXISTS v_blob_sidecars;
+
+CREATE TABLE new_t_blob_sidecars AS t_blob_sidecars
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_slot, f_index);
+
+INSERT INTO new_t_blob_sidecars
+SELECT * FROM t_blob_sidecars FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_blob_sidecars TO t_blob_sidecars_old;
+RENAME TABLE new_t_blob_sidecars TO t_blob_sidecars;
+DROP TABLE t_blob_sidecars_old;
+
+ALTER TABLE t_blob_sidecars
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_attestations
+DROP VIEW IF EXISTS v_attestations;
+
+CREATE TABLE new_t_attestations AS t_attestations
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_attestation_slot);
+
+INSERT INTO new_t_attestations
+SELECT * FROM t_attestations FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_attestations TO t_attestations_old;
+RENAME TABLE new_t_attestations TO t_attestations;
+DROP TABLE t_attestations_old;
+
+ALTER TABLE t_attestations
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_epoch_metrics_summary
+DROP VIEW IF EXISTS v_epoch_metrics_summary;
+
+CREATE TABLE new_t_epoch_metrics_summary AS t_epoch_metrics_summary
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_epoch);
+
+INSERT INTO new_t_epoch_metrics_summary
+SELECT * FROM t_epoch_metrics_summary FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_epoch_metrics_summary TO t_epoch_metrics_summary_old;
+RENAME TABLE new_t_epoch_metrics_summary TO t_epoch_metrics_summary;
+DROP TABLE t_epoch_metrics_summary_old;
+
+ALTER TABLE t_epoch_metrics_summary
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_validator_attestations
+DROP VIEW IF EXISTS v_validator_attestations;
+
+CREATE TABLE new_t_validator_attestations AS t_validator_attestations
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_epoch, f_val_idx);
+
+INSERT INTO new_t_validator_attestations
+SELECT * FROM t_validator_attestations FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_validator_attestations TO t_validator_attestations_old;
+RENAME TABLE new_t_validator_attestations TO t_validator_attestations;
+DROP TABLE t_validator_attestations_old;
+
+ALTER TABLE t_validator_attestations
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_validator_events
+DROP VIEW IF EXISTS v_validator_events;
+
+CREATE TABLE new_t_validator_events AS t_validator_events
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_epoch, f_val_idx, f_event);
+
+INSERT INTO new_t_validator_events
+SELECT * FROM t_validator_events FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_validator_events TO t_validator_events_old;
+RENAME TABLE new_t_validator_events TO t_validator_events;
+DROP TABLE t_validator_events_old;
+
+ALTER TABLE t_validator_events
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_validator_status_history
+DROP VIEW IF EXISTS v_validator_status_history;
+
+CREATE TABLE new_t_validator_status_history AS t_validator_status_history
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_val_idx, f_valid_from);
+
+INSERT INTO new_t_validator_status_history
+SELECT * FROM t_validator_status_history FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_validator_status_history TO t_validator_status_history_old;
+RENAME TABLE new_t_validator_status_history TO t_validator_status_history;
+DROP TABLE t_validator_status_history_old;
+
+ALTER TABLE t_validator_status_history
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+CREATE VIEW IF NOT EXISTS v_validator_status_history AS
+	SELECT
+		f_val_idx,
+		f_valid_from,
+		if(f_next_valid_from = 0, 18446744073709551615, f_next_valid_from - 1) AS f_valid_to,
+		f_status,
+		f_effective_balance_eth,
+		f_slashed,
+		f_withdrawal_credentials
+	FROM (
+		SELECT
+			*,
+			leadInFrame(f_valid_from) OVER (
+				PARTITION BY f_val_idx
+				ORDER BY f_valid_from ASC
+				ROWS BETWEEN CURRENT ROW AND 1 FOLLOWING) AS f_next_valid_from
+		FROM t_validator_status_history FINAL
+	);
+
+-- t_deposits
+DROP VIEW IF EXISTS v_deposits;
+
+CREATE TABLE new_t_deposits AS t_deposits
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_slot, f_index);
+
+INSERT INTO new_t_deposits
+SELECT * FROM t_deposits FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_deposits TO t_deposits_old;
+RENAME TABLE new_t_deposits TO t_deposits;
+DROP TABLE t_deposits_old;
+
+ALTER TABLE t_deposits
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_sync_committee_participation
+DROP VIEW IF EXISTS v_sync_committee_participation;
+
+CREATE TABLE new_t_sync_committee_participation AS t_sync_committee_participation
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_epoch, f_val_idx);
+
+INSERT INTO new_t_sync_committee_participation
+SELECT * FROM t_sync_committee_participation FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_sync_committee_participation TO t_sync_committee_participation_old;
+RENAME TABLE new_t_sync_committee_participation TO t_sync_committee_participation;
+DROP TABLE t_sync_committee_participation_old;
+
+ALTER TABLE t_sync_committee_participation
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_slashings
+DROP VIEW IF EXISTS v_slashings;
+
+CREATE TABLE new_t_slashings AS t_slashings
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_slot, f_slashed_validator_idx, f_slashing_type);
+
+INSERT INTO new_t_slashings
+SELECT * FROM t_slashings FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_slashings TO t_slashings_old;
+RENAME TABLE new_t_slashings TO t_slashings;
+DROP TABLE t_slashings_old;
+
+ALTER TABLE t_slashings
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_epoch_finality
+DROP VIEW IF EXISTS v_epoch_finality;
+
+CREATE TABLE new_t_epoch_finality AS t_epoch_finality
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_epoch);
+
+INSERT INTO new_t_epoch_finality
+SELECT * FROM t_epoch_finality FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_epoch_finality TO t_epoch_finality_old;
+RENAME TABLE new_t_epoch_finality TO t_epoch_finality;
+DROP TABLE t_epoch_finality_old;
+
+ALTER TABLE t_epoch_finality
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_rewards_audit
+DROP VIEW IF EXISTS v_rewards_audit;
+
+CREATE TABLE new_t_rewards_audit AS t_rewards_audit
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_epoch, f_val_idx, f_component);
+
+INSERT INTO new_t_rewards_audit
+SELECT * FROM t_rewards_audit FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_rewards_audit TO t_rewards_audit_old;
+RENAME TABLE new_t_rewards_audit TO t_rewards_audit;
+DROP TABLE t_rewards_audit_old;
+
+ALTER TABLE t_rewards_audit
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_proposer_duties
+DROP VIEW IF EXISTS v_proposer_duties;
+
+CREATE TABLE new_t_proposer_duties AS t_proposer_duties
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_proposer_slot, f_val_idx);
+
+INSERT INTO new_t_proposer_duties
+SELECT * FROM t_proposer_duties FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_proposer_duties TO t_proposer_duties_old;
+RENAME TABLE new_t_proposer_duties TO t_proposer_duties;
+DROP TABLE t_proposer_duties_old;
+
+ALTER TABLE t_proposer_duties
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
+
+-- t_validator_rewards_summary
+DROP VIEW IF EXISTS v_validator_rewards_summary;
+
+CREATE TABLE new_t_validator_rewards_summary AS t_validator_rewards_summary
+	ENGINE = ReplacingMergeTree()
+	ORDER BY (f_epoch, f_val_idx);
+
+INSERT INTO new_t_validator_rewards_summary
+SELECT * FROM t_validator_rewards_summary FINAL
+WHERE f_is_canonical;
+
+RENAME TABLE t_validator_rewards_summary TO t_validator_rewards_summary_old;
+RENAME TABLE new_t_validator_rewards_summary TO t_validator_rewards_summary;
+DROP TABLE t_validator_rewards_summary_old;
+
+ALTER TABLE t_validator_rewards_summary
+	DROP COLUMN f_version,
+	DROP COLUMN f_is_canonical;
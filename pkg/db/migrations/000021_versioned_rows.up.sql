@@ -0,0 +1,398 @@
+-- Tables rewritten on reorgs keep a version per row: a reorg inserts copies of the old rows
+-- with f_is_canonical = false, and the rows written afterwards for the same keys replace them.
+-- Engines cannot be altered, so every table is copied into a ReplacingMergeTree(f_version) one.
+
+-- t_block_metrics
+ALTER TABLE t_block_metrics
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_block_metrics AS t_block_metrics
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_slot);
+
+INSERT INTO new_t_block_metrics
+SELECT * FROM t_block_metrics;
+
+RENAME TABLE t_block_metrics TO t_block_metrics_old;
+RENAME TABLE new_t_block_metrics TO t_block_metrics;
+DROP TABLE t_block_metrics_old;
+
+CREATE VIEW IF NOT EXISTS v_block_metrics AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_block_metrics FINAL
+	WHERE f_is_canonical;
+
+-- t_transactions
+ALTER TABLE t_transactions
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_transactions AS t_transactions
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_slot, f_el_block_number, f_hash);
+
+INSERT INTO new_t_transactions
+SELECT * FROM t_transactions;
+
+RENAME TABLE t_transactions TO t_transactions_old;
+RENAME TABLE new_t_transactions TO t_transactions;
+DROP TABLE t_transactions_old;
+
+CREATE VIEW IF NOT EXISTS v_transactions AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_transactions FINAL
+	WHERE f_is_canonical;
+
+-- t_withdrawals
+ALTER TABLE t_withdrawals
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_withdrawals AS t_withdrawals
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_index);
+
+INSERT INTO new_t_withdrawals
+SELECT * FROM t_withdrawals;
+
+RENAME TABLE t_withdrawals TO t_withdrawals_old;
+RENAME TABLE new_t_withdrawals TO t_withdrawals;
+DROP TABLE t_withdrawals_old;
+
+CREATE VIEW IF NOT EXISTS v_withdrawals AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_withdrawals FINAL
+	WHERE f_is_canonical;
+
+-- t_voluntary_exits
+ALTER TABLE t_voluntary_exits
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_voluntary_exits AS t_voluntary_exits
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_slot, f_val_idx);
+
+INSERT INTO new_t_voluntary_exits
+SELECT * FROM t_voluntary_exits;
+
+RENAME TABLE t_voluntary_exits TO t_voluntary_exits_old;
+RENAME TABLE new_t_voluntary_exits TO t_voluntary_exits;
+DROP TABLE t_voluntary_exits_old;
+
+CREATE VIEW IF NOT EXISTS v_voluntary_exits AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_voluntary_exits FINAL
+	WHERE f_is_canonical;
+
+-- t_bls_to_execution_changes
+ALTER TABLE t_bls_to_execution_changes
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_bls_to_execution_changes AS t_bls_to_execution_changes
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_slot, f_val_idx);
+
+INSERT INTO new_t_bls_to_execution_changes
+SELECT * FROM t_bls_to_execution_changes;
+
+RENAME TABLE t_bls_to_execution_changes TO t_bls_to_execution_changes_old;
+RENAME TABLE new_t_bls_to_execution_changes TO t_bls_to_execution_changes;
+DROP TABLE t_bls_to_execution_changes_old;
+
+CREATE VIEW IF NOT EXISTS v_bls_to_execution_changes AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_bls_to_execution_changes FINAL
+	WHERE f_is_canonical;
+
+-- t_blob_sidecars
+ALTER TABLE t_blob_sidecars
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_blob_sidecars AS t_blob_sidecars
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_slot, f_index);
+
+INSERT INTO new_t_blob_sidecars
+SELECT * FROM t_blob_sidecars;
+
+RENAME TABLE t_blob_sidecars TO t_blob_sidecars_old;
+RENAME TABLE new_t_blob_sidecars TO t_blob_sidecars;
+DROP TABLE t_blob_sidecars_old;
+
+CREATE VIEW IF NOT EXISTS v_blob_sidecars AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_blob_sidecars FINAL
+	WHERE f_is_canonical;
+
+-- t_attestations
+ALTER TABLE t_attestations
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_attestations AS t_attestations
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_slot, f_attestation_slot, f_attestation_index);
+
+INSERT INTO new_t_attestations
+SELECT * FROM t_attestations;
+
+RENAME TABLE t_attestations TO t_attestations_old;
+RENAME TABLE new_t_attestations TO t_attestations;
+DROP TABLE t_attestations_old;
+
+CREATE VIEW IF NOT EXISTS v_attestations AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_attestations FINAL
+	WHERE f_is_canonical;
+
+-- t_epoch_metrics_summary
+ALTER TABLE t_epoch_metrics_summary
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_epoch_metrics_summary AS t_epoch_metrics_summary
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_epoch);
+
+INSERT INTO new_t_epoch_metrics_summary
+SELECT * FROM t_epoch_metrics_summary;
+
+RENAME TABLE t_epoch_metrics_summary TO t_epoch_metrics_summary_old;
+RENAME TABLE new_t_epoch_metrics_summary TO t_epoch_metrics_summary;
+DROP TABLE t_epoch_metrics_summary_old;
+
+CREATE VIEW IF NOT EXISTS v_epoch_metrics_summary AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_epoch_metrics_summary FINAL
+	WHERE f_is_canonical;
+
+-- t_validator_attestations
+ALTER TABLE t_validator_attestations
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_validator_attestations AS t_validator_attestations
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_epoch, f_val_idx);
+
+INSERT INTO new_t_validator_attestations
+SELECT * FROM t_validator_attestations;
+
+RENAME TABLE t_validator_attestations TO t_validator_attestations_old;
+RENAME TABLE new_t_validator_attestations TO t_validator_attestations;
+DROP TABLE t_validator_attestations_old;
+
+CREATE VIEW IF NOT EXISTS v_validator_attestations AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_validator_attestations FINAL
+	WHERE f_is_canonical;
+
+-- t_validator_events
+ALTER TABLE t_validator_events
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_validator_events AS t_validator_events
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_epoch, f_val_idx, f_event);
+
+INSERT INTO new_t_validator_events
+SELECT * FROM t_validator_events;
+
+RENAME TABLE t_validator_events TO t_validator_events_old;
+RENAME TABLE new_t_validator_events TO t_validator_events;
+DROP TABLE t_validator_events_old;
+
+CREATE VIEW IF NOT EXISTS v_validator_events AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_validator_events FINAL
+	WHERE f_is_canonical;
+
+-- t_validator_status_history
+ALTER TABLE t_validator_status_history
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_validator_status_history AS t_validator_status_history
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_val_idx, f_valid_from);
+
+INSERT INTO new_t_validator_status_history
+SELECT * FROM t_validator_status_history;
+
+RENAME TABLE t_validator_status_history TO t_validator_status_history_old;
+RENAME TABLE new_t_validator_status_history TO t_validator_status_history;
+DROP TABLE t_validator_status_history_old;
+
+-- the periods only use the canonical rows
+DROP VIEW IF EXISTS v_validator_status_history;
+CREATE VIEW IF NOT EXISTS v_validator_status_history AS
+	SELECT
+		f_val_idx,
+		f_valid_from,
+		if(f_next_valid_from = 0, 18446744073709551615, f_next_valid_from - 1) AS f_valid_to,
+		f_status,
+		f_effective_balance_eth,
+		f_slashed,
+		f_withdrawal_credentials
+	FROM (
+		SELECT
+			*,
+			leadInFrame(f_valid_from) OVER (
+				PARTITION BY f_val_idx
+				ORDER BY f_valid_from ASC
+				ROWS BETWEEN CURRENT ROW AND 1 FOLLOWING) AS f_next_valid_from
+		FROM t_validator_status_history FINAL
+		WHERE f_is_canonical
+	);
+
+-- t_deposits
+ALTER TABLE t_deposits
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_deposits AS t_deposits
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_slot, f_index);
+
+INSERT INTO new_t_deposits
+SELECT * FROM t_deposits;
+
+RENAME TABLE t_deposits TO t_deposits_old;
+RENAME TABLE new_t_deposits TO t_deposits;
+DROP TABLE t_deposits_old;
+
+CREATE VIEW IF NOT EXISTS v_deposits AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_deposits FINAL
+	WHERE f_is_canonical;
+
+-- t_sync_committee_participation
+ALTER TABLE t_sync_committee_participation
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_sync_committee_participation AS t_sync_committee_participation
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_epoch, f_val_idx);
+
+INSERT INTO new_t_sync_committee_participation
+SELECT * FROM t_sync_committee_participation;
+
+RENAME TABLE t_sync_committee_participation TO t_sync_committee_participation_old;
+RENAME TABLE new_t_sync_committee_participation TO t_sync_committee_participation;
+DROP TABLE t_sync_committee_participation_old;
+
+CREATE VIEW IF NOT EXISTS v_sync_committee_participation AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_sync_committee_participation FINAL
+	WHERE f_is_canonical;
+
+-- t_slashings
+ALTER TABLE t_slashings
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_slashings AS t_slashings
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_slot, f_slashed_validator_idx, f_slashing_type);
+
+INSERT INTO new_t_slashings
+SELECT * FROM t_slashings;
+
+RENAME TABLE t_slashings TO t_slashings_old;
+RENAME TABLE new_t_slashings TO t_slashings;
+DROP TABLE t_slashings_old;
+
+CREATE VIEW IF NOT EXISTS v_slashings AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_slashings FINAL
+	WHERE f_is_canonical;
+
+-- t_epoch_finality
+ALTER TABLE t_epoch_finality
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_epoch_finality AS t_epoch_finality
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_epoch);
+
+INSERT INTO new_t_epoch_finality
+SELECT * FROM t_epoch_finality;
+
+RENAME TABLE t_epoch_finality TO t_epoch_finality_old;
+RENAME TABLE new_t_epoch_finality TO t_epoch_finality;
+DROP TABLE t_epoch_finality_old;
+
+CREATE VIEW IF NOT EXISTS v_epoch_finality AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_epoch_finality FINAL
+	WHERE f_is_canonical;
+
+-- t_rewards_audit
+ALTER TABLE t_rewards_audit
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_rewards_audit AS t_rewards_audit
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_epoch, f_val_idx, f_component);
+
+INSERT INTO new_t_rewards_audit
+SELECT * FROM t_rewards_audit;
+
+RENAME TABLE t_rewards_audit TO t_rewards_audit_old;
+RENAME TABLE new_t_rewards_audit TO t_rewards_audit;
+DROP TABLE t_rewards_audit_old;
+
+CREATE VIEW IF NOT EXISTS v_rewards_audit AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_rewards_audit FINAL
+	WHERE f_is_canonical;
+
+-- t_proposer_duties
+ALTER TABLE t_proposer_duties
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_proposer_duties AS t_proposer_duties
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_proposer_slot, f_val_idx);
+
+INSERT INTO new_t_proposer_duties
+SELECT * FROM t_proposer_duties;
+
+RENAME TABLE t_proposer_duties TO t_proposer_duties_old;
+RENAME TABLE new_t_proposer_duties TO t_proposer_duties;
+DROP TABLE t_proposer_duties_old;
+
+CREATE VIEW IF NOT EXISTS v_proposer_duties AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_proposer_duties FINAL
+	WHERE f_is_canonical;
+
+-- t_validator_rewards_summary
+ALTER TABLE t_validator_rewards_summary
+	ADD COLUMN IF NOT EXISTS f_version UInt64 DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS f_is_canonical BOOL DEFAULT true;
+
+CREATE TABLE new_t_validator_rewards_summary AS t_validator_rewards_summary
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_epoch, f_val_idx);
+
+INSERT INTO new_t_validator_rewards_summary
+SELECT * FROM t_validator_rewards_summary;
+
+RENAME TABLE t_validator_rewards_summary TO t_validator_rewards_summary_old;
+RENAME TABLE new_t_validator_rewards_summary TO t_validator_rewards_summary;
+DROP TABLE t_validator_rewards_summary_old;
+
+CREATE VIEW IF NOT EXISTS v_validator_rewards_summary AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_validator_rewards_summary FINAL
+	WHERE f_is_canonical;
@@ -0,0 +1,34 @@
+DROP VIEW IF EXISTS v_block_metrics;
+ALTER TABLE t_block_metrics DROP COLUMN IF EXISTS f_finalized;
+CREATE VIEW IF NOT EXISTS v_block_metrics AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_block_metrics FINAL
+	WHERE f_is_canonical;
+
+DROP VIEW IF EXISTS v_attestations;
+ALTER TABLE t_attestations DROP COLUMN IF EXISTS f_finalized;
+CREATE VIEW IF NOT EXISTS v_attestations AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_attestations FINAL
+	WHERE f_is_canonical;
+
+DROP VIEW IF EXISTS v_transactions;
+ALTER TABLE t_transactions DROP COLUMN IF EXISTS f_finalized;
+CREATE VIEW IF NOT EXISTS v_transactions AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_transactions FINAL
+	WHERE f_is_canonical;
+
+DROP VIEW IF EXISTS v_withdrawals;
+ALTER TABLE t_withdrawals DROP COLUMN IF EXISTS f_finalized;
+CREATE VIEW IF NOT EXISTS v_withdrawals AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_withdrawals FINAL
+	WHERE f_is_canonical;
+
+DROP VIEW IF EXISTS v_epoch_metrics_summary;
+ALTER TABLE t_epoch_metrics_summary DROP COLUMN IF EXISTS f_finalized;
+CREATE VIEW IF NOT EXISTS v_epoch_metrics_summary AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_epoch_metrics_summary FINAL
+	WHERE f_is_canonical;
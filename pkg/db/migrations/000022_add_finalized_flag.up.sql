@@ -0,0 +1,42 @@
+-- Rows are finalized once the roots of their slot or epoch were checked after finality.
+-- The canonical views are recreated to include the new column.
+
+ALTER TABLE t_block_metrics ADD COLUMN IF NOT EXISTS f_finalized BOOL DEFAULT false;
+
+DROP VIEW IF EXISTS v_block_metrics;
+CREATE VIEW IF NOT EXISTS v_block_metrics AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_block_metrics FINAL
+	WHERE f_is_canonical;
+
+ALTER TABLE t_attestations ADD COLUMN IF NOT EXISTS f_finalized BOOL DEFAULT false;
+
+DROP VIEW IF EXISTS v_attestations;
+CREATE VIEW IF NOT EXISTS v_attestations AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_attestations FINAL
+	WHERE f_is_canonical;
+
+ALTER TABLE t_transactions ADD COLUMN IF NOT EXISTS f_finalized BOOL DEFAULT false;
+
+DROP VIEW IF EXISTS v_transactions;
+CREATE VIEW IF NOT EXISTS v_transactions AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_transactions FINAL
+	WHERE f_is_canonical;
+
+ALTER TABLE t_withdrawals ADD COLUMN IF NOT EXISTS f_finalized BOOL DEFAULT false;
+
+DROP VIEW IF EXISTS v_withdrawals;
+CREATE VIEW IF NOT EXISTS v_withdrawals AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_withdrawals FINAL
+	WHERE f_is_canonical;
+
+ALTER TABLE t_epoch_metrics_summary ADD COLUMN IF NOT EXISTS f_finalized BOOL DEFAULT false;
+
+DROP VIEW IF EXISTS v_epoch_metrics_summary;
+CREATE VIEW IF NOT EXISTS v_epoch_metrics_summary AS
+	SELECT * EXCEPT (f_version, f_is_canonical)
+	FROM t_epoch_metrics_summary FINAL
+	WHERE f_is_canonical;
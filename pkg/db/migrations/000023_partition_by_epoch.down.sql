@@ -0,0 +1,37 @@
+-- The rows written while the tables were partitioned are kept in <table>_partitioned,
+-- and the rows of <table>_unpartitioned are current again unless that table was dropped.
+
+CREATE TABLE IF NOT EXISTS t_attestations_unpartitioned AS t_attestations
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_slot, f_attestation_slot, f_attestation_index);
+
+RENAME TABLE t_attestations TO t_attestations_partitioned,
+	t_attestations_unpartitioned TO t_attestations;
+
+CREATE TABLE IF NOT EXISTS t_validator_attestations_unpartitioned AS t_validator_attestations
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_epoch, f_val_idx);
+
+RENAME TABLE t_validator_attestations TO t_validator_attestations_partitioned,
+	t_validator_attestations_unpartitioned TO t_validator_attestations;
+
+CREATE TABLE IF NOT EXISTS t_sync_committee_participation_unpartitioned AS t_sync_committee_participation
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_epoch, f_val_idx);
+
+RENAME TABLE t_sync_committee_participation TO t_sync_committee_participation_partitioned,
+	t_sync_committee_participation_unpartitioned TO t_sync_committee_participation;
+
+CREATE TABLE IF NOT EXISTS t_rewards_audit_unpartitioned AS t_rewards_audit
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_epoch, f_val_idx, f_component);
+
+RENAME TABLE t_rewards_audit TO t_rewards_audit_partitioned,
+	t_rewards_audit_unpartitioned TO t_rewards_audit;
+
+CREATE TABLE IF NOT EXISTS t_validator_rewards_summary_unpartitioned AS t_validator_rewards_summary
+	ENGINE = ReplacingMergeTree(f_version)
+	ORDER BY (f_epoch, f_val_idx);
+
+RENAME TABLE t_validator_rewards_summary TO t_validator_rewards_summary_partitioned,
+	t_validator_rewards_summary_unpartitioned TO t_validator_rewards_summary;
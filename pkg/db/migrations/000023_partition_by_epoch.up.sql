@@ -0,0 +1,51 @@
+-- The largest tables are partitioned by ranges of 1024 epochs, so that old epochs are removed
+-- by dropping whole partitions (val-window) or by a TTL, instead of DELETE mutations.
+-- ttl_only_drop_parts makes the TTL drop whole parts, which never mix partitions.
+-- The partition key cannot be altered and copying the rows would block for hours on big databases,
+-- so each table is replaced by an empty partitioned one and the existing rows are kept in <table>_unpartitioned,
+-- to be moved back or dropped by hand (see "Partitions" in the README).
+
+CREATE TABLE new_t_attestations AS t_attestations
+	ENGINE = ReplacingMergeTree(f_version)
+	PARTITION BY intDiv(f_epoch, 1024)
+	ORDER BY (f_slot, f_attestation_slot, f_attestation_index)
+	SETTINGS ttl_only_drop_parts = 1;
+
+RENAME TABLE t_attestations TO t_attestations_unpartitioned,
+	new_t_attestations TO t_attestations;
+
+CREATE TABLE new_t_validator_attestations AS t_validator_attestations
+	ENGINE = ReplacingMergeTree(f_version)
+	PARTITION BY intDiv(f_epoch, 1024)
+	ORDER BY (f_epoch, f_val_idx)
+	SETTINGS ttl_only_drop_parts = 1;
+
+RENAME TABLE t_validator_attestations TO t_validator_attestations_unpartitioned,
+	new_t_validator_attestations TO t_validator_attestations;
+
+CREATE TABLE new_t_sync_committee_participation AS t_sync_committee_participation
+	ENGINE = ReplacingMergeTree(f_version)
+	PARTITION BY intDiv(f_epoch, 1024)
+	ORDER BY (f_epoch, f_val_idx)
+	SETTINGS ttl_only_drop_parts = 1;
+
+RENAME TABLE t_sync_committee_participation TO t_sync_committee_participation_unpartitioned,
+	new_t_sync_committee_participation TO t_sync_committee_participation;
+
+CREATE TABLE new_t_rewards_audit AS t_rewards_audit
+	ENGINE = ReplacingMergeTree(f_version)
+	PARTITION BY intDiv(f_epoch, 1024)
+	ORDER BY (f_epoch, f_val_idx, f_component)
+	SETTINGS ttl_only_drop_parts = 1;
+
+RENAME TABLE t_rewards_audit TO t_rewards_audit_unpartitioned,
+	new_t_rewards_audit TO t_rewards_audit;
+
+CREATE TABLE new_t_validator_rewards_summary AS t_validator_rewards_summary
+	ENGINE = ReplacingMergeTree(f_version)
+	PARTITION BY intDiv(f_epoch, 1024)
+	ORDER BY (f_epoch, f_val_idx)
+	SETTINGS ttl_only_drop_parts = 1;
+
+RENAME TABLE t_validator_rewards_summary TO t_validator_rewards_summary_unpartitioned,
+	new_t_validator_rewards_summary TO t_validator_rewards_summary;
@@ -143,7 +143,7 @@ func (p *DBService) PersistOrphans(data []spec.AgnosticBlock) error {
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting orphans: %s", err.Error())
 	}
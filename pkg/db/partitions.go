@@ -0,0 +1,125 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	epochsPerPartition = uint64(1024) // PARTITION BY intDiv(f_epoch, 1024) in the migrations
+
+	selectPartitionsQuery = `
+		SELECT DISTINCT toUInt64(partition_id) AS f_partition
+		FROM system.parts
+		WHERE database = currentDatabase() AND table = '%s' AND active`
+
+	dropPartitionQuery = `
+		ALTER TABLE %s
+		DROP PARTITION ID '%d'`
+
+	// rows expire once the given number of epochs have passed since their epoch,
+	// tables are created with ttl_only_drop_parts so whole parts are dropped
+	retentionTTLQuery = `
+		ALTER TABLE %s
+		MODIFY TTL toDateTime(%d + (f_epoch + %d) * %d)`
+)
+
+// Large tables, partitioned by epoch range in ClickHouse so that old epochs are dropped without DELETE mutations
+var partitionedTables = map[string]bool{
+	valRewardsTable:        true,
+	valAttestationsTable:   true,
+	syncParticipationTable: true,
+	rewardsAuditTable:      true,
+	attestationsTable:      true,
+}
+
+func (p *DBService) partitioned(table string) bool {
+	return p.backend.name() == clickhouseScheme && partitionedTables[table]
+}
+
+// Partitions whose epochs are all lower or equal than the given one
+func partitionsUntil(partitions []uint64, epoch phase0.Epoch) []uint64 {
+	var complete []uint64
+	for _, partition := range partitions {
+		if (partition+1)*epochsPerPartition-1 <= uint64(epoch) {
+			complete = append(complete, partition)
+		}
+	}
+	sort.Slice(complete, func(i, j int) bool { return complete[i] < complete[j] })
+	return complete
+}
+
+// Drops the partitions of the table that only hold epochs lower or equal than the given one.
+// Rows of the partition that also holds later epochs are kept until the whole partition can be dropped.
+func (p *DBService) DropPartitionsUntil(table string, epoch phase0.Epoch) (int, error) {
+	if !p.partitioned(table) {
+		return 0, fmt.Errorf("table %s is not partitioned in %s", table, p.backend.name())
+	}
+
+	var dest []struct {
+		F_partition uint64 `ch:"f_partition"`
+	}
+	err := p.highSelect(fmt.Sprintf(selectPartitionsQuery, table), &dest)
+	if err != nil {
+		return 0, err
+	}
+
+	partitions := make([]uint64, len(dest))
+	for i := range dest {
+		partitions[i] = dest[i].F_partition
+	}
+
+	dropped := 0
+	for _, partition := range partitionsUntil(partitions, epoch) {
+		startTime := time.Now()
+
+		p.highMu.Lock()
+		err = p.backend.exec(p.ctx, fmt.Sprintf(dropPartitionQuery, table, partition))
+		p.highMu.Unlock()
+		if err != nil {
+			return dropped, fmt.Errorf("could not drop partition %d of %s: %s", partition, table, err)
+		}
+		log.Infof("dropped epochs %d to %d of %s in %f seconds", partition*epochsPerPartition,
+			(partition+1)*epochsPerPartition-1, table, time.Since(startTime).Seconds())
+		dropped++
+	}
+	return dropped, nil
+}
+
+// Sets a TTL of the given number of epochs on the partitioned tables, ClickHouse drops the expired parts by itself
+func (p *DBService) SetRetentionTTL(epochs phase0.Epoch) error {
+	if p.backend.name() != clickhouseScheme {
+		return fmt.Errorf("TTL retention is not supported by %s", p.backend.name())
+	}
+
+	genesis, err := p.RetrieveGenesis()
+	if err != nil {
+		return err
+	}
+	if genesis == 0 {
+		return fmt.Errorf("genesis time not found in the database, the blocks command writes it on the first run")
+	}
+
+	tables := make([]string, 0, len(partitionedTables))
+	for table := range partitionedTables {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	for _, table := range tables {
+		query := fmt.Sprintf(retentionTTLQuery, table, genesis, epochs, spec.SlotsPerEpoch*spec.SlotSeconds)
+
+		p.highMu.Lock()
+		err = p.backend.exec(p.ctx, query)
+		p.highMu.Unlock()
+		if err != nil {
+			return fmt.Errorf("could not set the TTL of %s: %s", table, err)
+		}
+	}
+	log.Infof("rows of %d epochs are kept in the partitioned tables", epochs)
+	return nil
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+func TestPartitionsUntil(t *testing.T) {
+	partitions := []uint64{3, 0, 2, 1}
+
+	tests := []struct {
+		epoch    phase0.Epoch
+		expected []uint64
+	}{
+		{1022, nil},
+		{1023, []uint64{0}},
+		{3000, []uint64{0, 1}},
+		{3071, []uint64{0, 1, 2}},
+		{100000, []uint64{0, 1, 2, 3}},
+	}
+	for _, test := range tests {
+		if got := partitionsUntil(partitions, test.epoch); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("epoch %d: expected partitions %v, got %v", test.epoch, test.expected, got)
+		}
+	}
+}
+
+func TestDeleteValidatorRewardsUntilWithoutPartitions(t *testing.T) {
+	store := newTestMemoryStore(t, "")
+	defer store.Finish()
+
+	var rewards []spec.ValidatorRewards
+	for epoch := phase0.Epoch(1); epoch <= 4; epoch++ {
+		rewards = append(rewards, spec.ValidatorRewards{ValidatorIndex: 1, Epoch: epoch})
+	}
+	store.PersistValidatorRewards(rewards)
+
+	// the memory backend has no partitions, rows are deleted
+	if _, err := store.DropPartitionsUntil(valRewardsTable, 2); err == nil {
+		t.Errorf("expected an error dropping partitions in the memory backend")
+	}
+	err := store.DeleteValidatorRewardsUntil(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows := store.Rows(valRewardsTable); len(rows) != 2 || rows[0]["f_epoch"] != uint64(3) {
+		t.Errorf("expected the rewards of epochs 3 and 4, got %v", rows)
+	}
+}
+
+var dropPartitionRegex = regexp.MustCompile(`DROP PARTITION ID '(\d+)'`)
+
+// Memory backend partitioned like ClickHouse: it lists and drops the partitions of the rows it holds
+type partitionedBackend struct {
+	*memoryBackend
+	dropped []uint64
+}
+
+func (b *partitionedBackend) name() string {
+	return clickhouseScheme
+}
+
+func (b *partitionedBackend) query(ctx context.Context, dest any, query string, args ...any) error {
+	partitions, ok := dest.(*[]struct {
+		F_partition uint64 `ch:"f_partition"`
+	})
+	if !ok {
+		return b.memoryBackend.query(ctx, dest, query, args...)
+	}
+	seen := make(map[uint64]bool)
+	for _, row := range b.rows(valRewardsTable) {
+		partition := row["f_epoch"].(uint64) / epochsPerPartition
+		if !seen[partition] {
+			seen[partition] = true
+			*partitions = append(*partitions, struct {
+				F_partition uint64 `ch:"f_partition"`
+			}{partition})
+		}
+	}
+	return nil
+}
+
+func (b *partitionedBackend) exec(ctx context.Context, query string, args ...any) error {
+	match := dropPartitionRegex.FindStringSubmatch(query)
+	if match == nil {
+		return b.memoryBackend.exec(ctx, query, args...)
+	}
+	partition, _ := strconv.ParseUint(match[1], 10, 64)
+	b.dropped = append(b.dropped, partition)
+	return b.memoryBackend.exec(ctx, fmt.Sprintf(deleteValidatorRewardsUntilEpochQuery, valRewardsTable),
+		phase0.Epoch((partition+1)*epochsPerPartition-1))
+}
+
+func TestDeleteValidatorRewardsUntilPartitioned(t *testing.T) {
+	store := newTestMemoryStore(t, "")
+	defer store.Finish()
+	backend := &partitionedBackend{memoryBackend: store.mem}
+	store.backend = backend
+
+	var rewards []spec.ValidatorRewards
+	for _, epoch := range []phase0.Epoch{10, 1023, 1024, 1500, 2047, 2048} {
+		rewards = append(rewards, spec.ValidatorRewards{ValidatorIndex: 1, Epoch: epoch})
+	}
+	store.PersistValidatorRewards(rewards)
+	store.Flush()
+
+	// the window ends inside the second partition
+	err := store.DeleteValidatorRewardsUntil(1500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(backend.dropped, []uint64{0}) {
+		t.Errorf("expected only the first partition to be dropped, got %v", backend.dropped)
+	}
+
+	var epochs []uint64
+	for _, row := range store.Rows(valRewardsTable) {
+		epochs = append(epochs, row["f_epoch"].(uint64))
+	}
+	// the second partition is kept whole, the window has not left it yet
+	if !reflect.DeepEqual(epochs, []uint64{1024, 1500, 2047, 2048}) {
+		t.Errorf("expected the rewards from epoch 1024 on, got %v", epochs)
+	}
+}
@@ -0,0 +1,255 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ClickHouse/ch-go/proto"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	postgresScheme        = "postgres"
+	postgresqlScheme      = "postgresql"
+	postgresMigrationsDir = "file://pkg/db/postgres_migrations"
+	postgresMigrateScheme = "pgx5" // scheme of the migrate pgx driver
+)
+
+// PostgreSQL backend: bulk inserts through COPY, selects and deletes through a connection pool
+type postgresBackend struct {
+	url string
+
+	pool *pgxpool.Pool
+}
+
+func newPostgresBackend(url string) *postgresBackend {
+	return &postgresBackend{
+		url: url,
+	}
+}
+
+func (b *postgresBackend) name() string {
+	return postgresScheme
+}
+
+func (b *postgresBackend) connect(ctx context.Context) error {
+	pool, err := pgxpool.New(ctx, b.url)
+	if err != nil {
+		return err
+	}
+
+	err = pool.Ping(ctx)
+	if err != nil {
+		pool.Close()
+		return err
+	}
+
+	b.pool = pool
+	log.Info("postgres pool is connected")
+	return nil
+}
+
+func (b *postgresBackend) migrate() error {
+	_, details, _ := strings.Cut(b.url, "://")
+	return runMigrations(postgresMigrationsDir, fmt.Sprintf("%s://%s", postgresMigrateScheme, details))
+}
+
+func (b *postgresBackend) newWriter(ctx context.Context) (dbWriter, error) {
+	conn, err := pgx.Connect(ctx, b.url)
+	if err != nil {
+		return nil, err
+	}
+	return &postgresWriter{conn: conn}, nil
+}
+
+func (b *postgresBackend) exec(ctx context.Context, query string, args ...any) error {
+	_, err := b.pool.Exec(ctx, query, args...)
+	return err
+}
+
+// Scans every row into the dest slice of structs, columns are assigned to the struct fields in order
+func (b *postgresBackend) query(ctx context.Context, dest any, query string, args ...any) error {
+	slice := reflect.ValueOf(dest)
+	if slice.Kind() != reflect.Pointer || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("query destination must be a pointer to a slice, got %T", dest)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+
+	rows, err := b.pool.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		elem := reflect.New(elemType).Elem()
+		fields := make([]any, elem.NumField())
+		for i := range fields {
+			fields[i] = elem.Field(i).Addr().Interface()
+		}
+
+		err = rows.Scan(fields...)
+		if err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, elem))
+	}
+	return rows.Err()
+}
+
+// Columns keep the ClickHouse type names, the migrations translate them into these PostgreSQL types
+var postgresColumnTypes = map[string]string{
+	"int8":    "Int64",
+	"int4":    "Int32",
+	"int2":    "Int16",
+	"float4":  "Float32",
+	"float8":  "Float64",
+	"bool":    "Bool",
+	"text":    "String",
+	"varchar": "String",
+	"_text":   "Array(String)",
+	"numeric": "UInt64",
+}
+
+func (b *postgresBackend) stream(ctx context.Context, query string, args []any, fn func(columns []Column, row []any) error) error {
+	rows, err := b.pool.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	fields := rows.FieldDescriptions()
+	columns := make([]Column, len(fields))
+	for i, field := range fields {
+		pgType, ok := rows.Conn().TypeMap().TypeForOID(field.DataTypeOID)
+		if !ok || postgresColumnTypes[pgType.Name] == "" {
+			return fmt.Errorf("unsupported type of column %s", field.Name)
+		}
+		columns[i] = Column{Name: field.Name, Type: postgresColumnTypes[pgType.Name]}
+	}
+
+	for rows.Next() {
+		row, err := rows.Values()
+		if err != nil {
+			return err
+		}
+		for i, value := range row {
+			row[i], err = postgresStreamValue(value)
+			if err != nil {
+				return fmt.Errorf("column %s: %s", columns[i].Name, err)
+			}
+		}
+
+		err = fn(columns, row)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Undoes the conversions of postgresValue for the types that have no Go equivalent
+func postgresStreamValue(value any) (any, error) {
+	switch v := value.(type) {
+	case pgtype.Numeric:
+		if !v.Valid || v.Int == nil || v.Exp < 0 {
+			return nil, fmt.Errorf("numeric %v is not an unsigned integer", v)
+		}
+		n := new(big.Int).Mul(v.Int, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(v.Exp)), nil))
+		if !n.IsUint64() {
+			return nil, fmt.Errorf("numeric %s does not fit in 64 bits", n)
+		}
+		return n.Uint64(), nil
+	case []any:
+		strs := make([]string, len(v))
+		for i, item := range v {
+			strs[i], _ = item.(string)
+		}
+		return strs, nil
+	default:
+		return value, nil
+	}
+}
+
+func (b *postgresBackend) close() {
+	if b.pool != nil {
+		b.pool.Close()
+	}
+}
+
+// Single connection, copies the columns into the table
+type postgresWriter struct {
+	conn *pgx.Conn
+}
+
+func (w *postgresWriter) insert(ctx context.Context, task writeTask) error {
+	columns := make([]string, len(task.input))
+	for i, col := range task.input {
+		columns[i] = col.Name
+	}
+
+	_, err := w.conn.CopyFrom(
+		ctx,
+		pgx.Identifier{task.table},
+		columns,
+		pgx.CopyFromSlice(task.rows, func(i int) ([]any, error) {
+			row := make([]any, len(task.input))
+			for j, col := range task.input {
+				value, err := postgresValue(col.Name, col.Data, i)
+				if err != nil {
+					return nil, fmt.Errorf("column %s: %s", col.Name, err)
+				}
+				row[j] = value
+			}
+			return row, nil
+		}))
+	return err
+}
+
+func (w *postgresWriter) isClosed() bool {
+	return w.conn.IsClosed()
+}
+
+func (w *postgresWriter) close() {
+	w.conn.Close(context.Background())
+}
+
+// Epochs that can hold FAR_FUTURE_EPOCH, NUMERIC(20) in the migrations instead of BIGINT
+var postgresNumericColumns = map[string]bool{
+	"f_activation_epoch": true,
+	"f_withdrawal_epoch": true,
+	"f_exit_epoch":       true,
+}
+
+// Converts the row of a ClickHouse column into a value PostgreSQL can store, there are no unsigned types
+func postgresValue(name string, col proto.ColInput, i int) (any, error) {
+	value, err := columnValue(col, i)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case uint64:
+		if postgresNumericColumns[name] {
+			return pgtype.Numeric{Int: new(big.Int).SetUint64(v), Valid: true}, nil
+		}
+		if v > math.MaxInt64 {
+			return nil, fmt.Errorf("%d does not fit in a BIGINT column", v)
+		}
+		return int64(v), nil
+	case uint16:
+		return int32(v), nil
+	case uint8:
+		return int16(v), nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,33 @@
+DROP VIEW IF EXISTS v_validator_status_history;
+
+DROP TABLE IF EXISTS t_block_metrics;
+DROP TABLE IF EXISTS t_attestations;
+DROP TABLE IF EXISTS t_orphans;
+DROP TABLE IF EXISTS t_epoch_metrics_summary;
+DROP TABLE IF EXISTS t_pool_summary;
+DROP TABLE IF EXISTS t_proposer_duties;
+DROP TABLE IF EXISTS t_status;
+DROP TABLE IF EXISTS t_transactions;
+DROP TABLE IF EXISTS t_validator_last_status;
+DROP TABLE IF EXISTS t_validator_rewards_summary;
+DROP TABLE IF EXISTS t_withdrawals;
+DROP TABLE IF EXISTS t_reorgs;
+DROP TABLE IF EXISTS t_finalized_checkpoint;
+DROP TABLE IF EXISTS t_genesis;
+DROP TABLE IF EXISTS t_eth2_pubkeys;
+DROP TABLE IF EXISTS t_head_events;
+DROP TABLE IF EXISTS t_blob_sidecars;
+DROP TABLE IF EXISTS t_blob_sidecars_events;
+DROP TABLE IF EXISTS t_block_rewards;
+DROP TABLE IF EXISTS t_validator_attestations;
+DROP TABLE IF EXISTS t_sync_committee_participation;
+DROP TABLE IF EXISTS t_sync_committees;
+DROP TABLE IF EXISTS t_rewards_audit;
+DROP TABLE IF EXISTS t_epoch_finality;
+DROP TABLE IF EXISTS t_validator_events;
+DROP TABLE IF EXISTS t_validator_event_types;
+DROP TABLE IF EXISTS t_validator_status_history;
+DROP TABLE IF EXISTS t_bls_to_execution_changes;
+DROP TABLE IF EXISTS t_deposits;
+DROP TABLE IF EXISTS t_voluntary_exits;
+DROP TABLE IF EXISTS t_slashings;
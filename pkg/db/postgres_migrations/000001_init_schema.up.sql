@@ -0,0 +1,387 @@
+-- PostgreSQL has no unsigned types: UInt64 columns are BIGINT, except the epochs that can hold FAR_FUTURE_EPOCH
+
+CREATE TABLE IF NOT EXISTS t_block_metrics(
+	f_timestamp BIGINT,
+	f_epoch BIGINT,
+	f_slot BIGINT,
+	f_graffiti TEXT,
+	f_proposer_index BIGINT,
+	f_proposed BOOLEAN,
+	f_attestations BIGINT,
+	f_deposits BIGINT,
+	f_proposer_slashings BIGINT,
+	f_attester_slashings BIGINT,
+	f_voluntary_exits BIGINT,
+	f_sync_bits BIGINT,
+	f_el_fee_recp TEXT,
+	f_el_gas_limit BIGINT,
+	f_el_gas_used BIGINT,
+	f_el_base_fee_per_gas BIGINT,
+	f_el_block_hash TEXT,
+	f_el_transactions BIGINT,
+	f_el_block_number BIGINT,
+	f_payload_size_bytes BIGINT,
+	f_ssz_size_bytes REAL,
+	f_snappy_size_bytes REAL,
+	f_compression_time_ms REAL,
+	f_decompression_time_ms REAL);
+CREATE INDEX IF NOT EXISTS i_block_metrics_slot ON t_block_metrics (f_slot);
+
+CREATE TABLE IF NOT EXISTS t_attestations(
+	f_timestamp BIGINT,
+	f_epoch BIGINT,
+	f_slot BIGINT,
+	f_attestation_index BIGINT,
+	f_attestation_slot BIGINT,
+	f_attestation_beacon_block_root TEXT,
+	f_attestation_source_epoch BIGINT,
+	f_attestation_source_root TEXT,
+	f_attestation_target_epoch BIGINT,
+	f_attestation_target_root TEXT);
+CREATE INDEX IF NOT EXISTS i_attestations_slot ON t_attestations (f_slot);
+
+CREATE TABLE IF NOT EXISTS t_orphans(
+	f_timestamp BIGINT,
+	f_epoch BIGINT,
+	f_slot BIGINT,
+	f_graffiti TEXT,
+	f_proposer_index BIGINT,
+	f_proposed BOOLEAN,
+	f_attestations BIGINT,
+	f_deposits BIGINT,
+	f_proposer_slashings BIGINT,
+	f_attester_slashings BIGINT,
+	f_voluntary_exits BIGINT,
+	f_sync_bits BIGINT,
+	f_el_fee_recp TEXT,
+	f_el_gas_limit BIGINT,
+	f_el_gas_used BIGINT,
+	f_el_base_fee_per_gas BIGINT,
+	f_el_block_hash TEXT,
+	f_el_transactions BIGINT,
+	f_el_block_number BIGINT,
+	f_payload_size_bytes BIGINT,
+	f_ssz_size_bytes REAL,
+	f_snappy_size_bytes REAL,
+	f_compression_time_ms REAL,
+	f_decompression_time_ms REAL);
+CREATE INDEX IF NOT EXISTS i_orphans_slot ON t_orphans (f_slot);
+
+CREATE TABLE IF NOT EXISTS t_epoch_metrics_summary(
+	f_epoch BIGINT,
+	f_slot BIGINT,
+	f_num_att BIGINT,
+	f_num_att_vals BIGINT,
+	f_num_vals BIGINT,
+	f_total_balance_eth REAL,
+	f_att_effective_balance_eth BIGINT,
+	f_source_att_effective_balance_eth BIGINT,
+	f_target_att_effective_balance_eth BIGINT,
+	f_head_att_effective_balance_eth BIGINT,
+	f_total_effective_balance_eth BIGINT,
+	f_missing_source BIGINT,
+	f_missing_target BIGINT,
+	f_missing_head BIGINT,
+	f_timestamp BIGINT,
+	f_num_slashed_vals BIGINT DEFAULT 0,
+	f_num_active_vals BIGINT DEFAULT 0,
+	f_num_exited_vals BIGINT DEFAULT 0,
+	f_num_in_activation_vals BIGINT DEFAULT 0,
+	f_epochs_since_finality BIGINT DEFAULT 0,
+	f_inactivity_leak BOOLEAN DEFAULT false);
+CREATE INDEX IF NOT EXISTS i_epoch_metrics_summary_epoch ON t_epoch_metrics_summary (f_epoch);
+
+CREATE TABLE IF NOT EXISTS t_pool_summary(
+	f_pool_name TEXT,
+	f_epoch BIGINT,
+	aggregated_rewards BIGINT,
+	aggregated_max_rewards BIGINT,
+	count_sync_committee BIGINT,
+	count_missing_source BIGINT,
+	count_missing_target BIGINT,
+	count_missing_head BIGINT,
+	count_expected_attestations BIGINT,
+	proposed_blocks_performance BIGINT,
+	missed_blocks_performance BIGINT,
+	number_active_vals BIGINT,
+	avg_inclusion_delay REAL);
+CREATE INDEX IF NOT EXISTS i_pool_summary_epoch ON t_pool_summary (f_epoch, f_pool_name);
+
+CREATE TABLE IF NOT EXISTS t_proposer_duties(
+	f_val_idx BIGINT,
+	f_proposer_slot BIGINT,
+	f_proposed BOOLEAN);
+CREATE INDEX IF NOT EXISTS i_proposer_duties_slot ON t_proposer_duties (f_proposer_slot, f_val_idx);
+
+CREATE TABLE IF NOT EXISTS t_status(
+	f_id BIGINT PRIMARY KEY,
+	f_status TEXT);
+
+INSERT INTO t_status VALUES(0, 'in_activation_queue');
+INSERT INTO t_status VALUES(1, 'active');
+INSERT INTO t_status VALUES(2, 'slashed');
+INSERT INTO t_status VALUES(3, 'exited');
+
+CREATE TABLE IF NOT EXISTS t_transactions(
+	f_tx_idx BIGINT DEFAULT 0,
+	f_tx_type BIGINT,
+	f_chain_id BIGINT,
+	f_data TEXT DEFAULT '',
+	f_gas BIGINT,
+	f_gas_price BIGINT,
+	f_gas_tip_cap BIGINT,
+	f_gas_fee_cap BIGINT,
+	f_value REAL,
+	f_nonce BIGINT,
+	f_to TEXT DEFAULT '',
+	f_hash TEXT,
+	f_size BIGINT,
+	f_slot BIGINT,
+	f_el_block_number BIGINT,
+	f_timestamp BIGINT,
+	f_from TEXT DEFAULT '',
+	f_contract_address TEXT DEFAULT '',
+	f_blob_gas_used BIGINT,
+	f_blob_gas_price BIGINT,
+	f_blob_gas_limit BIGINT,
+	f_blob_gas_fee_cap BIGINT);
+CREATE INDEX IF NOT EXISTS i_transactions_slot ON t_transactions (f_slot, f_el_block_number, f_hash);
+
+CREATE TABLE IF NOT EXISTS t_validator_last_status(
+	f_val_idx BIGINT,
+	f_epoch BIGINT,
+	f_balance_eth REAL,
+	f_status SMALLINT,
+	f_slashed BOOLEAN,
+	f_activation_epoch NUMERIC(20),
+	f_withdrawal_epoch NUMERIC(20),
+	f_exit_epoch NUMERIC(20),
+	f_public_key TEXT,
+	f_withdrawal_credentials TEXT DEFAULT '',
+	f_withdrawal_type SMALLINT DEFAULT 0,
+	f_withdrawal_address TEXT DEFAULT '');
+CREATE INDEX IF NOT EXISTS i_validator_last_status_val_idx ON t_validator_last_status (f_val_idx);
+
+CREATE TABLE IF NOT EXISTS t_validator_rewards_summary(
+	f_val_idx BIGINT,
+	f_epoch BIGINT,
+	f_balance_eth REAL,
+	f_reward BIGINT,
+	f_max_reward BIGINT,
+	f_max_att_reward BIGINT,
+	f_max_sync_reward BIGINT,
+	f_base_reward BIGINT,
+	f_in_sync_committee BOOLEAN,
+	f_missing_source BOOLEAN,
+	f_missing_target BOOLEAN,
+	f_missing_head BOOLEAN,
+	f_status SMALLINT,
+	f_block_api_reward BIGINT,
+	f_block_experimental_reward BIGINT,
+	f_inclusion_delay SMALLINT DEFAULT 0,
+	f_source_reward BIGINT DEFAULT 0,
+	f_target_reward BIGINT DEFAULT 0,
+	f_head_reward BIGINT DEFAULT 0,
+	f_sync_reward BIGINT DEFAULT 0,
+	f_proposer_reward BIGINT DEFAULT 0,
+	f_slashing_reward BIGINT DEFAULT 0,
+	f_inactivity_reward BIGINT DEFAULT 0,
+	f_inactivity_score BIGINT DEFAULT 0);
+CREATE INDEX IF NOT EXISTS i_validator_rewards_summary_epoch ON t_validator_rewards_summary (f_epoch, f_val_idx);
+
+CREATE TABLE IF NOT EXISTS t_withdrawals(
+	f_slot BIGINT,
+	f_index BIGINT,
+	f_val_idx BIGINT,
+	f_address TEXT,
+	f_amount BIGINT);
+CREATE INDEX IF NOT EXISTS i_withdrawals_index ON t_withdrawals (f_index);
+CREATE INDEX IF NOT EXISTS i_withdrawals_slot ON t_withdrawals (f_slot);
+
+CREATE TABLE IF NOT EXISTS t_reorgs(
+	f_slot BIGINT,
+	f_depth BIGINT,
+	f_old_head_block_root TEXT,
+	f_new_head_block_root TEXT,
+	f_old_head_state_root TEXT,
+	f_new_head_state_root TEXT);
+CREATE INDEX IF NOT EXISTS i_reorgs_slot ON t_reorgs (f_slot);
+
+CREATE TABLE IF NOT EXISTS t_finalized_checkpoint(
+	f_id BIGINT DEFAULT 0,
+	f_block_root TEXT,
+	f_state_root TEXT,
+	f_epoch BIGINT);
+CREATE INDEX IF NOT EXISTS i_finalized_checkpoint_epoch ON t_finalized_checkpoint (f_epoch);
+
+CREATE TABLE IF NOT EXISTS t_genesis(
+	f_genesis_time BIGINT PRIMARY KEY);
+
+CREATE TABLE IF NOT EXISTS t_eth2_pubkeys(
+	f_val_idx BIGINT PRIMARY KEY,
+	f_public_key TEXT,
+	f_pool_name TEXT,
+	f_pool TEXT);
+
+CREATE TABLE IF NOT EXISTS t_head_events(
+	f_slot BIGINT,
+	f_block TEXT,
+	f_state TEXT,
+	f_epoch_transition BOOLEAN,
+	f_current_duty_dependent_root TEXT,
+	f_previous_duty_dependent_root TEXT,
+	f_arrival_timestamp BIGINT);
+CREATE INDEX IF NOT EXISTS i_head_events_block ON t_head_events (f_block);
+
+CREATE TABLE IF NOT EXISTS t_blob_sidecars(
+	f_blob_hash TEXT DEFAULT '',
+	f_tx_hash TEXT DEFAULT '',
+	f_slot BIGINT,
+	f_index SMALLINT,
+	f_kzg_commitment TEXT DEFAULT '',
+	f_kzg_proof TEXT DEFAULT '',
+	f_ending_0s BIGINT);
+CREATE INDEX IF NOT EXISTS i_blob_sidecars_slot ON t_blob_sidecars (f_slot, f_index);
+
+CREATE TABLE IF NOT EXISTS t_blob_sidecars_events(
+	f_arrival_timestamp_ms BIGINT,
+	f_blob_hash TEXT DEFAULT '',
+	f_slot BIGINT);
+CREATE INDEX IF NOT EXISTS i_blob_sidecars_events_blob_hash ON t_blob_sidecars_events (f_blob_hash);
+
+CREATE TABLE IF NOT EXISTS t_block_rewards(
+	f_slot BIGINT,
+	f_reward_fees BIGINT,
+	f_burnt_fees BIGINT,
+	f_cl_manual_reward BIGINT,
+	f_cl_api_reward BIGINT,
+	f_relays TEXT[],
+	f_builder_pubkey TEXT,
+	f_bid_commission BIGINT);
+CREATE INDEX IF NOT EXISTS i_block_rewards_slot ON t_block_rewards (f_slot);
+
+CREATE TABLE IF NOT EXISTS t_validator_attestations(
+	f_val_idx BIGINT,
+	f_epoch BIGINT,
+	f_att_slot BIGINT,
+	f_committee_index BIGINT,
+	f_inclusion_slot BIGINT,
+	f_inclusion_delay SMALLINT);
+CREATE INDEX IF NOT EXISTS i_validator_attestations_epoch ON t_validator_attestations (f_epoch, f_val_idx);
+
+CREATE TABLE IF NOT EXISTS t_sync_committee_participation(
+	f_val_idx BIGINT,
+	f_epoch BIGINT,
+	f_missed_slots BIGINT,
+	f_num_missed SMALLINT);
+CREATE INDEX IF NOT EXISTS i_sync_committee_participation_epoch ON t_sync_committee_participation (f_epoch, f_val_idx);
+
+CREATE TABLE IF NOT EXISTS t_sync_committees(
+	f_period BIGINT,
+	f_position INTEGER,
+	f_val_idx BIGINT,
+	f_public_key TEXT);
+CREATE INDEX IF NOT EXISTS i_sync_committees_period ON t_sync_committees (f_period, f_position);
+
+CREATE TABLE IF NOT EXISTS t_rewards_audit(
+	f_val_idx BIGINT,
+	f_epoch BIGINT,
+	f_component TEXT,
+	f_api_reward BIGINT,
+	f_computed_reward BIGINT);
+CREATE INDEX IF NOT EXISTS i_rewards_audit_epoch ON t_rewards_audit (f_epoch, f_val_idx, f_component);
+
+CREATE TABLE IF NOT EXISTS t_epoch_finality(
+	f_epoch BIGINT,
+	f_slot BIGINT,
+	f_justification_bits SMALLINT,
+	f_prev_justified_epoch BIGINT,
+	f_prev_justified_root TEXT,
+	f_current_justified_epoch BIGINT,
+	f_current_justified_root TEXT,
+	f_finalized_epoch BIGINT,
+	f_finalized_root TEXT,
+	f_epochs_to_finality BIGINT);
+CREATE INDEX IF NOT EXISTS i_epoch_finality_epoch ON t_epoch_finality (f_epoch);
+
+CREATE TABLE IF NOT EXISTS t_validator_events(
+	f_val_idx BIGINT,
+	f_epoch BIGINT,
+	f_event SMALLINT);
+CREATE INDEX IF NOT EXISTS i_validator_events_epoch ON t_validator_events (f_epoch, f_val_idx, f_event);
+
+CREATE TABLE IF NOT EXISTS t_validator_event_types(
+	f_id SMALLINT PRIMARY KEY,
+	f_event TEXT);
+
+INSERT INTO t_validator_event_types VALUES(0, 'deposited');
+INSERT INTO t_validator_event_types VALUES(1, 'eligible');
+INSERT INTO t_validator_event_types VALUES(2, 'activated');
+INSERT INTO t_validator_event_types VALUES(3, 'exit_initiated');
+INSERT INTO t_validator_event_types VALUES(4, 'exited');
+INSERT INTO t_validator_event_types VALUES(5, 'slashed');
+INSERT INTO t_validator_event_types VALUES(6, 'withdrawable');
+INSERT INTO t_validator_event_types VALUES(7, 'fully_withdrawn');
+INSERT INTO t_validator_event_types VALUES(8, 'withdrawal_credentials_change');
+
+CREATE TABLE IF NOT EXISTS t_validator_status_history(
+	f_val_idx BIGINT,
+	f_valid_from BIGINT,
+	f_status SMALLINT,
+	f_effective_balance_eth REAL,
+	f_slashed BOOLEAN,
+	f_withdrawal_credentials TEXT);
+CREATE INDEX IF NOT EXISTS i_validator_status_history_val_idx ON t_validator_status_history (f_val_idx, f_valid_from);
+CREATE INDEX IF NOT EXISTS i_validator_status_history_valid_from ON t_validator_status_history (f_valid_from);
+
+-- epochs might be processed in any order, so the end of each period is resolved at query time
+CREATE OR REPLACE VIEW v_validator_status_history AS
+	SELECT
+		f_val_idx,
+		f_valid_from,
+		COALESCE(LEAD(f_valid_from) OVER (PARTITION BY f_val_idx ORDER BY f_valid_from ASC) - 1,
+			18446744073709551615::NUMERIC(20)) AS f_valid_to,
+		f_status,
+		f_effective_balance_eth,
+		f_slashed,
+		f_withdrawal_credentials
+	FROM t_validator_status_history;
+
+CREATE TABLE IF NOT EXISTS t_bls_to_execution_changes(
+	f_slot BIGINT,
+	f_epoch BIGINT,
+	f_val_idx BIGINT,
+	f_from_bls_pubkey TEXT,
+	f_to_execution_address TEXT);
+CREATE INDEX IF NOT EXISTS i_bls_to_execution_changes_slot ON t_bls_to_execution_changes (f_slot, f_val_idx);
+
+CREATE TABLE IF NOT EXISTS t_deposits(
+	f_slot BIGINT,
+	f_epoch BIGINT,
+	f_index BIGINT,
+	f_public_key TEXT,
+	f_withdrawal_credentials TEXT,
+	f_amount BIGINT,
+	f_val_idx BIGINT,
+	f_deposit_type SMALLINT,
+	f_el_block_number BIGINT,
+	f_el_tx_hash TEXT,
+	f_el_sender TEXT);
+CREATE INDEX IF NOT EXISTS i_deposits_slot ON t_deposits (f_slot, f_index);
+CREATE INDEX IF NOT EXISTS i_deposits_epoch ON t_deposits (f_epoch);
+
+CREATE TABLE IF NOT EXISTS t_voluntary_exits(
+	f_val_idx BIGINT,
+	f_epoch BIGINT,
+	f_slot BIGINT);
+CREATE INDEX IF NOT EXISTS i_voluntary_exits_slot ON t_voluntary_exits (f_slot, f_val_idx);
+
+CREATE TABLE IF NOT EXISTS t_slashings(
+	f_slashed_validator_idx BIGINT,
+	f_slot BIGINT,
+	f_epoch BIGINT,
+	f_slashing_type SMALLINT,
+	f_whistleblower_idx BIGINT,
+	f_whistleblower_reward BIGINT);
+CREATE INDEX IF NOT EXISTS i_slashings_slot ON t_slashings (f_slot, f_slashed_validator_idx, f_slashing_type);
+CREATE INDEX IF NOT EXISTS i_slashings_epoch ON t_slashings (f_epoch);
@@ -0,0 +1,5 @@
+ALTER TABLE t_block_metrics DROP COLUMN IF EXISTS f_finalized;
+ALTER TABLE t_attestations DROP COLUMN IF EXISTS f_finalized;
+ALTER TABLE t_transactions DROP COLUMN IF EXISTS f_finalized;
+ALTER TABLE t_withdrawals DROP COLUMN IF EXISTS f_finalized;
+ALTER TABLE t_epoch_metrics_summary DROP COLUMN IF EXISTS f_finalized;
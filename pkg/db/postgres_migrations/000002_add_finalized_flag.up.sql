@@ -0,0 +1,7 @@
+-- Rows are finalized once the roots of their slot or epoch were checked after finality
+
+ALTER TABLE t_block_metrics ADD COLUMN IF NOT EXISTS f_finalized BOOLEAN DEFAULT false;
+ALTER TABLE t_attestations ADD COLUMN IF NOT EXISTS f_finalized BOOLEAN DEFAULT false;
+ALTER TABLE t_transactions ADD COLUMN IF NOT EXISTS f_finalized BOOLEAN DEFAULT false;
+ALTER TABLE t_withdrawals ADD COLUMN IF NOT EXISTS f_finalized BOOLEAN DEFAULT false;
+ALTER TABLE t_epoch_metrics_summary ADD COLUMN IF NOT EXISTS f_finalized BOOLEAN DEFAULT false;
@@ -25,6 +25,27 @@ var (
 		Help:      "Last slot processed with metrics",
 	})
 
+	TrackedSyncCommitteeMembers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: strings.ToLower(utils.CliName),
+		Subsystem: modName,
+		Name:      "tracked_sync_committee_members",
+		Help:      "Number of tracked validators (with a pool) in the current sync committee",
+	})
+
+	WriteQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: strings.ToLower(utils.CliName),
+		Subsystem: modName,
+		Name:      "write_queue_depth",
+		Help:      "Number of batches waiting for a db writer",
+	})
+
+	SpilledRowsPending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: strings.ToLower(utils.CliName),
+		Subsystem: modName,
+		Name:      "spilled_rows_pending",
+		Help:      "Number of rows in the spill directory waiting to be replayed",
+	})
+
 	// List of metrics that we are going to export
 	RowsPersisted = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -91,6 +112,17 @@ func (r *DBService) initMonitorMetrics() {
 		transactionsTable,
 		valLastStatusTable,
 		valRewardsTable,
+		valAttestationsTable,
+		syncParticipationTable,
+		syncCommitteesTable,
+		rewardsAuditTable,
+		epochFinalityTable,
+		valEventsTable,
+		valStatusHistoryTable,
+		blsToExecutionChangesTable,
+		depositsTable,
+		voluntaryExitsTable,
+		slashingsTable,
 		withdrawalsTable}
 
 	for _, tableName := range tablesArr {
@@ -109,6 +141,9 @@ func (r *DBService) GetPrometheusMetrics() *metrics.MetricsModule {
 
 	metricsMod.AddIndvMetric(r.lastProcessedSlotMetric())
 	metricsMod.AddIndvMetric(r.lastProcessedEpochMetric())
+	metricsMod.AddIndvMetric(r.trackedSyncCommitteeMembersMetric())
+	metricsMod.AddIndvMetric(r.writeQueueDepthMetric())
+	metricsMod.AddIndvMetric(r.spilledRowsPendingMetric())
 	return metricsMod
 }
 
@@ -200,6 +235,72 @@ func (r *DBService) lastProcessedSlotMetric() *metrics.IndvMetrics {
 	return lastSlot
 }
 
+func (r *DBService) trackedSyncCommitteeMembersMetric() *metrics.IndvMetrics {
+	initFn := func() error {
+		prometheus.MustRegister(TrackedSyncCommitteeMembers)
+		return nil
+	}
+	updateFn := func() (interface{}, error) {
+		count, err := r.RetrieveTrackedSyncCommitteeMembers()
+		if err != nil {
+			return nil, err
+		}
+		TrackedSyncCommitteeMembers.Set(float64(count))
+		return count, nil
+	}
+	trackedMembers, err := metrics.NewIndvMetrics(
+		"tracked_sync_committee_members",
+		initFn,
+		updateFn,
+	)
+	if err != nil {
+		return nil
+	}
+	return trackedMembers
+}
+
+func (r *DBService) writeQueueDepthMetric() *metrics.IndvMetrics {
+	initFn := func() error {
+		prometheus.MustRegister(WriteQueueDepth)
+		return nil
+	}
+	updateFn := func() (interface{}, error) {
+		depth := r.WriteQueueDepth()
+		WriteQueueDepth.Set(float64(depth))
+		return depth, nil
+	}
+	queueDepth, err := metrics.NewIndvMetrics(
+		"write_queue_depth",
+		initFn,
+		updateFn,
+	)
+	if err != nil {
+		return nil
+	}
+	return queueDepth
+}
+
+func (r *DBService) spilledRowsPendingMetric() *metrics.IndvMetrics {
+	initFn := func() error {
+		prometheus.MustRegister(SpilledRowsPending)
+		return nil
+	}
+	updateFn := func() (interface{}, error) {
+		rows := r.PendingSpilledRows()
+		SpilledRowsPending.Set(float64(rows))
+		return rows, nil
+	}
+	spilledRows, err := metrics.NewIndvMetrics(
+		"spilled_rows_pending",
+		initFn,
+		updateFn,
+	)
+	if err != nil {
+		return nil
+	}
+	return spilledRows
+}
+
 func (r *DBService) getMonitorMetrics() map[string]DBMonitorMetrics {
 	r.metricsMu.RLock()
 	defer r.metricsMu.RUnlock()
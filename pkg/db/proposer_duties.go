@@ -18,7 +18,7 @@ var (
 
 	deleteProposerDutiesQuery = `
 	DELETE FROM %s
-	WHERE f_proposer_slot/%v = $1;
+	WHERE f_proposer_slot >= $1 AND f_proposer_slot < $2;
 `
 )
 
@@ -55,7 +55,7 @@ func (p *DBService) PersistDuties(data []spec.ProposerDuty) error {
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting proposer duties: %s", err.Error())
 	}
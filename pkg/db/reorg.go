@@ -61,7 +61,7 @@ func (p *DBService) PersistReorgs(data []api.ChainReorgEvent) error {
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting reorgs: %s", err.Error())
 	}
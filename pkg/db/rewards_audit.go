@@ -0,0 +1,68 @@
+package db
+
+import (
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	rewardsAuditTable       = "t_rewards_audit"
+	insertRewardsAuditQuery = `
+	INSERT INTO %s (
+		f_val_idx,
+		f_epoch,
+		f_component,
+		f_api_reward,
+		f_computed_reward)
+		VALUES`
+
+	deleteRewardsAuditQuery = `
+		DELETE FROM %s
+		WHERE f_epoch = $1;
+`
+)
+
+func rewardsAuditInput(audits []spec.RewardsAudit) proto.Input {
+	// one object per column
+	var (
+		f_val_idx         proto.ColUInt64
+		f_epoch           proto.ColUInt64
+		f_component       proto.ColStr
+		f_api_reward      proto.ColInt64
+		f_computed_reward proto.ColInt64
+	)
+
+	for _, audit := range audits {
+		f_val_idx.Append(uint64(audit.ValIdx))
+		f_epoch.Append(uint64(audit.Epoch))
+		f_component.Append(audit.Component)
+		f_api_reward.Append(audit.ApiReward)
+		f_computed_reward.Append(audit.ComputedReward)
+	}
+
+	return proto.Input{
+		{Name: "f_val_idx", Data: f_val_idx},
+		{Name: "f_epoch", Data: f_epoch},
+		{Name: "f_component", Data: f_component},
+		{Name: "f_api_reward", Data: f_api_reward},
+		{Name: "f_computed_reward", Data: f_computed_reward},
+	}
+}
+
+func (p *DBService) PersistRewardsAudit(data []spec.RewardsAudit) error {
+	persistObj := PersistableObject[spec.RewardsAudit]{
+		input: rewardsAuditInput,
+		table: rewardsAuditTable,
+		query: insertRewardsAuditQuery,
+	}
+
+	for _, item := range data {
+		persistObj.Append(item)
+	}
+
+	err := bufferPersist(p, persistObj)
+	if err != nil {
+		log.Errorf("error persisting rewards audit: %s", err.Error())
+	}
+	return err
+}
@@ -7,9 +7,7 @@ import (
 	"sync"
 	"time"
 
-	"github.com/ClickHouse/ch-go"
 	"github.com/ClickHouse/ch-go/proto"
-	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/migalabs/goteth/pkg/spec"
 	"github.com/sirupsen/logrus"
@@ -21,8 +19,8 @@ var (
 	log      = logrus.WithField(
 		"module", modName,
 	)
-	MAX_BATCH_QUEUE       = 1000
-	MAX_EPOCH_BATCH_QUEUE = 1
+	MAX_BATCH_QUEUE       = 1000 // rows buffered per table before inserting them
+	MAX_EPOCH_BATCH_QUEUE = 1    // epochs processed before inserting every buffered row
 )
 
 type DBServiceOption func(*DBService) error
@@ -32,15 +30,37 @@ type DBService struct {
 	ctx           context.Context
 	connectionUrl string // the url might not be necessary (better to remove it?¿)
 
-	migrationUrl string
-
-	lowLevelClient  *ch.Client  // for bulk loads, mainly insert
-	highLevelClient driver.Conn // for side tasks, like Select and Delete
+	backend  dbBackend // database engine, selected by the url scheme
+	readOnly bool      // connected without migrations nor writers, see ConnectReadOnly
 
 	monitorMetrics map[string]*DBMonitorMetrics // map table and metrics
-	lowMu          sync.Mutex
 	highMu         sync.Mutex
 	metricsMu      sync.RWMutex
+
+	buffers        map[string]persistBuffer // rows waiting to be inserted, per table
+	bufferedEpochs int                      // epochs processed since the last flush
+	bufferMu       sync.Mutex
+
+	writerNum     int            // number of db writers, each one with its own connection
+	writers       []dbWriter     // connections used by the db writers
+	writeQueue    chan writeTask // batches waiting to be inserted
+	writersWg     sync.WaitGroup // running db writers
+	pendingWrites int            // batches queued or being inserted
+	pendingMu     sync.Mutex
+	pendingCond   *sync.Cond   // signals there are no pending writes
+	writersClosed bool         // the write queue no longer accepts batches
+	writeMu       sync.RWMutex // protects the write queue from being closed while sending
+
+	spillDir    string     // where failed batches are written until they are replayed
+	spillSeq    uint64     // keeps spill files unique and ordered
+	spilledRows int64      // rows waiting in the spill directory
+	spillMu     sync.Mutex // only one replay at a time
+
+	sink       Sink            // where persisted rows are published, optional
+	sinkTables map[string]bool // tables published to the sink, all when empty
+
+	versions  writeVersions  // version of the rows of versioned tables
+	finalized finalizedEpoch // rows up to this epoch are written finalized
 }
 
 func New(ctx context.Context, url string, options ...DBServiceOption) (*DBService, error) {
@@ -49,7 +69,11 @@ func New(ctx context.Context, url string, options ...DBServiceOption) (*DBServic
 		ctx:            ctx,
 		connectionUrl:  url,
 		monitorMetrics: make(map[string]*DBMonitorMetrics),
+		buffers:        make(map[string]persistBuffer),
+		writerNum:      defaultWriterNum,
+		writeQueue:     make(chan writeTask, writeQueueSize),
 	}
+	pService.pendingCond = sync.NewCond(&pService.pendingMu)
 
 	pService.initMonitorMetrics()
 
@@ -60,26 +84,67 @@ func New(ctx context.Context, url string, options ...DBServiceOption) (*DBServic
 		}
 	}
 
+	pService.backend, err = newBackend(pService.connectionUrl)
+
 	return pService, err
 }
 
 func (s *DBService) Connect() error {
 
-	err := s.ConnectLowLevel(true)
+	err := s.connectBackend()
 	if err != nil {
-		return fmt.Errorf("low level db driver error: %s", err)
+		return err
 	}
 
-	err = s.makeMigrations()
+	err = s.backend.migrate()
 	if err != nil {
 		return fmt.Errorf("migration error: %s", err)
 	}
 
-	err = s.ConnectHighLevel()
+	err = s.startWriters()
+	if err != nil {
+		return fmt.Errorf("db writers error: %s", err)
+	}
+
+	go s.runFlushTicker()
+
+	if s.spillDir != "" {
+		err = s.loadSpilled()
+		if err != nil {
+			return fmt.Errorf("spill directory error: %s", err)
+		}
+		go s.runSpillRetrier()
+	}
+
+	return nil
+}
+
+// Connects to read the tables only: the schema is not migrated and no writer,
+// flush ticker nor spill retrier is started, persisting returns an error
+func (s *DBService) ConnectReadOnly() error {
+
+	err := s.connectBackend()
 	if err != nil {
-		return fmt.Errorf("high level db driver error: %s", err)
+		return err
 	}
 
+	s.readOnly = true
+	s.stopWriters()
+	return nil
+}
+
+func (s *DBService) connectBackend() error {
+
+	err := s.backend.connect(s.ctx)
+	if err != nil {
+		// database may be in idle state, wait 30 seconds and retry another time
+		log.Warning("database could be idle, service will retry to connect another time in 30 seconds ...")
+		time.Sleep(30 * time.Second)
+		err = s.backend.connect(s.ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("%s db driver error: %s", s.backend.name(), err)
+	}
 	return nil
 }
 
@@ -92,8 +157,19 @@ func WithUrl(url string) DBServiceOption {
 
 func (p *DBService) Finish() {
 
-	p.lowLevelClient.Close()
-	p.highLevelClient.Close()
+	if !p.readOnly {
+		p.Flush()
+		p.stopWriters()
+		err := p.replaySpilled()
+		if err != nil {
+			log.Warnf("%d spilled rows remain in %s, they will be replayed on the next run: %s",
+				p.PendingSpilledRows(), p.spillDir, err)
+		}
+	}
+	p.backend.close()
+	if p.sink != nil {
+		p.sink.Close()
+	}
 	log.Infof("Routines finished...")
 	log.Infof("closing connection to database server...")
 	log.Infof("connection to database server closed...")
@@ -139,9 +215,21 @@ func (d DeletableObject) Args() []any {
 
 type Input[T any] func(t T) proto.Input
 
-type PersistableObject[
-	T spec.AgnosticBlock |
+// Types that can be persisted through a PersistableObject
+type Persistable interface {
+	spec.AgnosticBlock |
 		spec.Attestation |
+		spec.ValidatorAttestation |
+		spec.ValidatorSyncParticipation |
+		spec.SyncCommitteeMember |
+		spec.RewardsAudit |
+		spec.EpochFinality |
+		spec.ValidatorEvent |
+		spec.ValidatorStatusHistory |
+		spec.BLSToExecutionChange |
+		spec.Deposit |
+		spec.VoluntaryExit |
+		spec.Slashing |
 		spec.Epoch |
 		api.FinalizedCheckpointEvent |
 		int64 |
@@ -154,7 +242,10 @@ type PersistableObject[
 		HeadEvent |
 		spec.AgnosticBlobSidecar |
 		spec.BlobSideCarEventWraper |
-		BlockReward] struct {
+		BlockReward
+}
+
+type PersistableObject[T Persistable] struct {
 	table string
 	query string
 	data  []T
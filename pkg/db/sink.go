@@ -0,0 +1,78 @@
+package db
+
+// Receives the rows of every persisted batch and every delete, to stream them out of the database
+type Sink interface {
+	Publish(table string, rows []Row) error
+	PublishDelete(table string, conditions []Condition) error // tombstone of the deleted rows
+	Close()
+}
+
+// Tables whose rows are deleted by slot when a block is reorged
+var slotKeyedTables = map[string]bool{
+	blocksTable:                true,
+	attestationsTable:          true,
+	transactionsTable:          true,
+	withdrawalsTable:           true,
+	voluntaryExitsTable:        true,
+	blsToExecutionChangesTable: true,
+	blobsTable:                 true,
+}
+
+// Column identifying the rows that are deleted together: the slot for block tables, the epoch column otherwise
+func KeyColumn(table string) (string, bool) {
+	if slotKeyedTables[table] {
+		return "f_slot", true
+	}
+	column, ok := epochColumns[table]
+	return column.name, ok
+}
+
+// Publishes the given tables to the sink once they are persisted, every table when none is given
+func WithSink(sink Sink, tables ...string) DBServiceOption {
+	return func(s *DBService) error {
+		s.sink = sink
+		s.sinkTables = make(map[string]bool)
+		for _, table := range tables {
+			s.sinkTables[table] = true
+		}
+		return nil
+	}
+}
+
+func (s *DBService) sinkTable(table string) bool {
+	return s.sink != nil && (len(s.sinkTables) == 0 || s.sinkTables[table])
+}
+
+func (s *DBService) publish(task writeTask) {
+	if !s.sinkTable(task.table) {
+		return
+	}
+
+	rows, err := taskRows(task)
+	if err == nil {
+		// the version and the finality stamp only serve the database engine,
+		// taskRows builds new maps so the task written or spilled keeps them
+		for _, row := range rows {
+			delete(row, versionColumn)
+			delete(row, finalizedColumn)
+		}
+		err = s.sink.Publish(task.table, rows)
+	}
+	if err != nil {
+		log.Errorf("could not publish %d rows of %s: %s", task.rows, task.table, err)
+	}
+}
+
+func (s *DBService) publishDelete(obj DeletableObject) {
+	if !s.sinkTable(obj.Table()) {
+		return
+	}
+
+	_, conditions, err := parseDelete(obj.Query(), obj.Args())
+	if err == nil {
+		err = s.sink.PublishDelete(obj.Table(), conditions)
+	}
+	if err != nil {
+		log.Errorf("could not publish delete of %s: %s", obj.Table(), err)
+	}
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+type recordedDelete struct {
+	table      string
+	conditions []Condition
+}
+
+// Keeps everything it receives
+type recorderSink struct {
+	mu      sync.Mutex
+	rows    map[string][]Row
+	deletes []recordedDelete
+	closed  bool
+}
+
+func (r *recorderSink) Publish(table string, rows []Row) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rows[table] = append(r.rows[table], rows...)
+	return nil
+}
+
+func (r *recorderSink) PublishDelete(table string, conditions []Condition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deletes = append(r.deletes, recordedDelete{table: table, conditions: conditions})
+	return nil
+}
+
+func (r *recorderSink) Close() {
+	r.closed = true
+}
+
+func TestSinkPublishes(t *testing.T) {
+	sink := &recorderSink{rows: make(map[string][]Row)}
+	store, err := NewMemoryStore(context.Background(), "", WithSink(sink, blocksTable, proposerDutiesTable))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store.PersistBlocks([]spec.AgnosticBlock{testBlock(32), testBlock(33)})
+	store.PersistEpochs([]spec.Epoch{{Epoch: 2}})
+	store.PersistDuties([]spec.ProposerDuty{{ProposerSlot: 33}})
+	store.Flush()
+
+	if len(sink.rows[blocksTable]) != 2 || sink.rows[blocksTable][1]["f_slot"] != uint64(33) {
+		t.Errorf("expected the 2 blocks to be published, got %v", sink.rows[blocksTable])
+	}
+	for _, row := range sink.rows[blocksTable] {
+		if _, ok := row[finalizedColumn]; ok {
+			t.Errorf("the internal %s column is published: %v", finalizedColumn, row)
+		}
+	}
+	for _, row := range store.Rows(blocksTable) {
+		if _, ok := row[finalizedColumn]; !ok {
+			t.Errorf("publishing dropped the %s column of the stored row: %v", finalizedColumn, row)
+		}
+	}
+	if len(sink.rows[epochsTable]) != 0 {
+		t.Errorf("epochs are not published to the sink, got %v", sink.rows[epochsTable])
+	}
+
+	// only the deletes of the published tables are tombstoned
+	err = store.DeleteBlockMetrics(33)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.DeleteStateMetrics(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Finish()
+
+	expected := []recordedDelete{
+		{blocksTable, []Condition{{"f_slot", "=", 33}}},
+		{proposerDutiesTable, []Condition{{"f_proposer_slot", ">=", 2 * spec.SlotsPerEpoch}, {"f_proposer_slot", "<", 3 * spec.SlotsPerEpoch}}},
+	}
+	if len(sink.deletes) != len(expected) {
+		t.Fatalf("expected %d tombstones, got %v", len(expected), sink.deletes)
+	}
+	for i, tombstone := range expected {
+		got := sink.deletes[i]
+		if got.table != tombstone.table || len(got.conditions) != len(tombstone.conditions) {
+			t.Errorf("expected tombstone %v, got %v", tombstone, got)
+			continue
+		}
+		for j := range tombstone.conditions {
+			if got.conditions[j] != tombstone.conditions[j] {
+				t.Errorf("expected condition %s, got %s", tombstone.conditions[j], got.conditions[j])
+			}
+		}
+	}
+	if !sink.closed {
+		t.Errorf("the sink is closed when the service finishes")
+	}
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	slashingsTable       = "t_slashings"
+	insertSlashingsQuery = `
+	INSERT INTO %s (
+		f_slashed_validator_idx,
+		f_slot,
+		f_epoch,
+		f_slashing_type,
+		f_whistleblower_idx,
+		f_whistleblower_reward)
+		VALUES`
+
+	deleteSlashingsQuery = `
+		DELETE FROM %s
+		WHERE f_epoch = $1;`
+)
+
+func slashingsInput(slashings []spec.Slashing) proto.Input {
+	// one object per column
+	var (
+		f_slashed_validator_idx proto.ColUInt64
+		f_slot                  proto.ColUInt64
+		f_epoch                 proto.ColUInt64
+		f_slashing_type         proto.ColUInt8
+		f_whistleblower_idx     proto.ColUInt64
+		f_whistleblower_reward  proto.ColUInt64
+	)
+
+	for _, slashing := range slashings {
+		f_slashed_validator_idx.Append(uint64(slashing.SlashedValidator))
+		f_slot.Append(uint64(slashing.Slot))
+		f_epoch.Append(uint64(slashing.Epoch))
+		f_slashing_type.Append(uint8(slashing.SlashingType))
+		f_whistleblower_idx.Append(uint64(slashing.Whistleblower))
+		f_whistleblower_reward.Append(uint64(slashing.WhistleblowerReward))
+	}
+
+	return proto.Input{
+		{Name: "f_slashed_validator_idx", Data: f_slashed_validator_idx},
+		{Name: "f_slot", Data: f_slot},
+		{Name: "f_epoch", Data: f_epoch},
+		{Name: "f_slashing_type", Data: f_slashing_type},
+		{Name: "f_whistleblower_idx", Data: f_whistleblower_idx},
+		{Name: "f_whistleblower_reward", Data: f_whistleblower_reward},
+	}
+}
+
+func (p *DBService) PersistSlashings(data []spec.Slashing) error {
+	persistObj := PersistableObject[spec.Slashing]{
+		input: slashingsInput,
+		table: slashingsTable,
+		query: insertSlashingsQuery,
+	}
+
+	for _, item := range data {
+		persistObj.Append(item)
+	}
+
+	err := bufferPersist(p, persistObj)
+	if err != nil {
+		log.Errorf("error persisting slashings: %s", err.Error())
+	}
+	return err
+}
@@ -0,0 +1,290 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+var (
+	spillFileExt          = ".wal"
+	spillFileVersion      = "goteth-wal-v1"
+	spillRetryMinInterval = 5 * time.Second // first retry after a failed replay, doubled on every failure
+	spillRetryMaxInterval = 5 * time.Minute
+)
+
+// Batches that could not be inserted are written to dir and replayed until they are persisted
+func WithSpillDir(dir string) DBServiceOption {
+	return func(s *DBService) error {
+		if dir == "" {
+			return nil
+		}
+		err := os.MkdirAll(dir, 0o755)
+		if err != nil {
+			return fmt.Errorf("could not create spill directory %s: %s", dir, err)
+		}
+		s.spillDir = dir
+		return nil
+	}
+}
+
+// Writes the batch to the spill directory, the rows are lost when there is no spill directory
+func (s *DBService) spill(task writeTask) {
+	if s.spillDir == "" {
+		log.Errorf("%d rows to %s were lost, no spill directory configured", task.rows, task.table)
+		return
+	}
+
+	seq := atomic.AddUint64(&s.spillSeq, 1)
+	name := fmt.Sprintf("%020d-%06d-%s%s", time.Now().UnixNano(), seq, task.table, spillFileExt)
+	path := filepath.Join(s.spillDir, name)
+
+	err := writeSpillFile(path, task)
+	if err != nil {
+		log.Errorf("%d rows to %s were lost, could not spill them: %s", task.rows, task.table, err)
+		return
+	}
+	atomic.AddInt64(&s.spilledRows, int64(task.rows))
+	log.Warnf("%d rows to %s spilled to %s, they will be retried", task.rows, task.table, path)
+}
+
+// Counts the rows left in the spill directory by a previous run
+func (s *DBService) loadSpilled() error {
+	files, err := s.spillFiles()
+	if err != nil {
+		return err
+	}
+
+	var rows int64
+	for _, path := range files {
+		task, err := readSpillFile(path)
+		if err != nil {
+			log.Errorf("could not read spill file %s: %s", path, err)
+			continue
+		}
+		rows += int64(task.rows)
+	}
+	atomic.StoreInt64(&s.spilledRows, rows)
+
+	if len(files) > 0 {
+		log.Infof("found %d spilled rows in %d files, they will be replayed", rows, len(files))
+	}
+	return nil
+}
+
+// Inserts the spilled batches in the order they were written, stopping at the first failure
+func (s *DBService) replaySpilled() error {
+	if s.spillDir == "" {
+		return nil
+	}
+	s.spillMu.Lock()
+	defer s.spillMu.Unlock()
+
+	files, err := s.spillFiles()
+	if err != nil || len(files) == 0 {
+		return err
+	}
+
+	writer, err := s.backend.newWriter(s.ctx)
+	if err != nil {
+		return err
+	}
+	defer writer.close()
+
+	for _, path := range files {
+		task, err := readSpillFile(path)
+		if err != nil {
+			// keep the file aside so that it does not block the rest
+			log.Errorf("could not read spill file %s, moving it aside: %s", path, err)
+			os.Rename(path, path+".corrupted")
+			continue
+		}
+
+		err = s.write(writer, task)
+		if err != nil {
+			return fmt.Errorf("could not replay %s: %s", path, err)
+		}
+
+		err = os.Remove(path)
+		if err != nil {
+			log.Errorf("could not remove replayed spill file %s: %s", path, err)
+		}
+		atomic.AddInt64(&s.spilledRows, -int64(task.rows))
+		log.Infof("replayed %d spilled rows to %s", task.rows, task.table)
+	}
+	return nil
+}
+
+// Replays the spilled batches, backing off while the database is unavailable
+func (s *DBService) runSpillRetrier() {
+	interval := spillRetryMinInterval
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		err := s.replaySpilled()
+		if err != nil {
+			interval = min(interval*2, spillRetryMaxInterval)
+			log.Warnf("%d spilled rows pending, retrying in %s: %s", s.PendingSpilledRows(), interval, err)
+			continue
+		}
+		interval = spillRetryMinInterval
+	}
+}
+
+// Spill files sorted by creation
+func (s *DBService) spillFiles() ([]string, error) {
+	entries, err := os.ReadDir(s.spillDir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read spill directory %s: %s", s.spillDir, err)
+	}
+
+	files := make([]string, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), spillFileExt) {
+			continue
+		}
+		files = append(files, filepath.Join(s.spillDir, entry.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Whether spilled rows of the table wait to be replayed
+func (s *DBService) spilledTable(table string) (bool, error) {
+	if s.spillDir == "" {
+		return false, nil
+	}
+	files, err := s.spillFiles()
+	if err != nil {
+		return false, err
+	}
+	for _, path := range files {
+		if strings.HasSuffix(path, "-"+table+spillFileExt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *DBService) PendingSpilledRows() int64 {
+	return atomic.LoadInt64(&s.spilledRows)
+}
+
+// The file is written under a temporary name and renamed once synced, so it is never read half written
+func writeSpillFile(path string, task writeTask) error {
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(encodeSpill(task))
+	if err == nil {
+		err = f.Sync()
+	}
+	closeErr := f.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func readSpillFile(path string) (writeTask, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return writeTask{}, err
+	}
+	return decodeSpill(data)
+}
+
+// Columns are stored in the ClickHouse native format, preceded by their name and type
+func encodeSpill(task writeTask) []byte {
+	var b proto.Buffer
+
+	b.PutString(spillFileVersion)
+	b.PutString(task.query)
+	b.PutString(task.table)
+	b.PutInt(task.rows)
+	b.PutInt(len(task.input))
+
+	for _, col := range task.input {
+		b.PutString(col.Name)
+		b.PutString(string(col.Data.Type()))
+		if state, ok := col.Data.(proto.StateEncoder); ok {
+			state.EncodeState(&b)
+		}
+		col.Data.EncodeColumn(&b)
+	}
+	return b.Buf
+}
+
+func decodeSpill(data []byte) (writeTask, error) {
+	var task writeTask
+	r := proto.NewReader(bytes.NewReader(data))
+
+	version, err := r.Str()
+	if err != nil {
+		return task, err
+	}
+	if version != spillFileVersion {
+		return task, fmt.Errorf("unknown spill file version %s", version)
+	}
+	if task.query, err = r.Str(); err != nil {
+		return task, err
+	}
+	if task.table, err = r.Str(); err != nil {
+		return task, err
+	}
+	if task.rows, err = r.Int(); err != nil {
+		return task, err
+	}
+	columns, err := r.Int()
+	if err != nil {
+		return task, err
+	}
+
+	for i := 0; i < columns; i++ {
+		name, err := r.Str()
+		if err != nil {
+			return task, err
+		}
+		colType, err := r.Str()
+		if err != nil {
+			return task, err
+		}
+
+		col := &proto.ColAuto{}
+		err = col.Infer(proto.ColumnType(colType))
+		if err != nil {
+			return task, fmt.Errorf("column %s: %s", name, err)
+		}
+		if state, ok := col.Data.(proto.StateDecoder); ok {
+			err = state.DecodeState(r)
+			if err != nil {
+				return task, fmt.Errorf("column %s: %s", name, err)
+			}
+		}
+		err = col.DecodeColumn(r, task.rows)
+		if err != nil {
+			return task, fmt.Errorf("column %s: %s", name, err)
+		}
+		task.input = append(task.input, proto.InputColumn{Name: name, Data: col.Data})
+	}
+	return task, nil
+}
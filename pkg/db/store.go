@@ -0,0 +1,68 @@
+package db
+
+import (
+	"time"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/metrics"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+// Everything the analyzer needs from the storage, regardless of the database behind it
+type Store interface {
+	Connect() error
+	Finish()
+	GetPrometheusMetrics() *metrics.MetricsModule
+	InitGenesis(apiGenesis time.Time) error
+	EndEpoch()
+
+	// block metrics
+	PersistBlocks(data []spec.AgnosticBlock) error
+	PersistOrphans(data []spec.AgnosticBlock) error
+	PersistAttestations(data []spec.AgnosticBlock) error
+	PersistTransactions(data []spec.AgnosticTransaction) error
+	PersistWithdrawals(data []spec.Withdrawal) error
+	PersistBlockRewards(data []BlockReward) error
+	PersistBlobSidecars(data []*spec.AgnosticBlobSidecar) error
+	PersistBlobSidecarsEvents(data []spec.BlobSideCarEventWraper) error
+	PersistBLSToExecutionChanges(data []spec.BLSToExecutionChange) error
+	PersistVoluntaryExits(data []spec.VoluntaryExit) error
+
+	// state metrics
+	PersistEpochs(data []spec.Epoch) error
+	PersistEpochFinality(data []spec.EpochFinality) error
+	PersistDuties(data []spec.ProposerDuty) error
+	PersistValidatorRewards(data []spec.ValidatorRewards) error
+	PersistValidatorAttestations(data []spec.ValidatorAttestation) error
+	PersistValLastStatus(data []spec.ValidatorLastStatus) error
+	PersistValidatorStatusHistory(data []spec.ValidatorStatusHistory) error
+	PersistValidatorEvents(data []spec.ValidatorEvent) error
+	PersistDeposits(data []spec.Deposit) error
+	PersistSlashings(data []spec.Slashing) error
+	PersistSyncCommitteeMembers(data []spec.SyncCommitteeMember) error
+	PersistSyncCommitteeParticipation(data []spec.ValidatorSyncParticipation) error
+	PersistRewardsAudit(data []spec.RewardsAudit) error
+	InsertPoolSummary(epoch phase0.Epoch) error
+
+	// events
+	PersistHeadEvents(data []HeadEvent) error
+	PersistReorgs(data []api.ChainReorgEvent) error
+	PersistFinalized(data []api.FinalizedCheckpointEvent) error
+
+	// reorgs, finality and retention
+	MarkFinalized(epoch phase0.Epoch) error
+	SetFinalized(epoch phase0.Epoch)
+	DeleteBlockMetrics(slot phase0.Slot) error
+	DeleteAttestationsMetrics(slot phase0.Slot) error
+	DeleteStateMetrics(epoch phase0.Epoch) error
+	DeleteValLastStatus(epoch phase0.Epoch) error
+	DeleteValidatorRewardsUntil(epoch phase0.Epoch) error
+	SetRetentionTTL(epochs phase0.Epoch) error
+
+	RetrieveLastEpoch() (phase0.Epoch, error)
+	RetrieveLastSlot() (phase0.Slot, error)
+	RetrieveValidatorStatuses(epoch phase0.Epoch) (map[phase0.ValidatorIndex]spec.ValidatorStatusHistory, error)
+}
+
+var _ Store = &DBService{}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	syncParticipationTable                = "t_sync_committee_participation"
+	insertSyncCommitteeParticipationQuery = `
+	INSERT INTO %s (
+		f_val_idx,
+		f_epoch,
+		f_missed_slots,
+		f_num_missed)
+		VALUES`
+
+	deleteSyncCommitteeParticipationQuery = `
+		DELETE FROM %s
+		WHERE f_epoch = $1;
+`
+)
+
+func syncCommitteeParticipationInput(participations []spec.ValidatorSyncParticipation) proto.Input {
+	// one object per column
+	var (
+		f_val_idx      proto.ColUInt64
+		f_epoch        proto.ColUInt64
+		f_missed_slots proto.ColUInt64
+		f_num_missed   proto.ColUInt8
+	)
+
+	for _, participation := range participations {
+		f_val_idx.Append(uint64(participation.ValIdx))
+		f_epoch.Append(uint64(participation.Epoch))
+		f_missed_slots.Append(participation.MissedSlots)
+		f_num_missed.Append(uint8(participation.NumMissed))
+	}
+
+	return proto.Input{
+		{Name: "f_val_idx", Data: f_val_idx},
+		{Name: "f_epoch", Data: f_epoch},
+		{Name: "f_missed_slots", Data: f_missed_slots},
+		{Name: "f_num_missed", Data: f_num_missed},
+	}
+}
+
+func (p *DBService) PersistSyncCommitteeParticipation(data []spec.ValidatorSyncParticipation) error {
+	persistObj := PersistableObject[spec.ValidatorSyncParticipation]{
+		input: syncCommitteeParticipationInput,
+		table: syncParticipationTable,
+		query: insertSyncCommitteeParticipationQuery,
+	}
+
+	for _, item := range data {
+		persistObj.Append(item)
+	}
+
+	err := bufferPersist(p, persistObj)
+	if err != nil {
+		log.Errorf("error persisting sync committee participation: %s", err.Error())
+	}
+	return err
+}
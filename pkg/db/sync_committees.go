@@ -0,0 +1,85 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	syncCommitteesTable             = "t_sync_committees"
+	eth2PubkeysTable                = "t_eth2_pubkeys" // validators with a pool, filled outside goteth
+	insertSyncCommitteeMembersQuery = `
+	INSERT INTO %s (
+		f_period,
+		f_position,
+		f_val_idx,
+		f_public_key)
+		VALUES`
+
+	// count tracked validators (the ones with a pool) in the last persisted sync committee
+	selectTrackedSyncCommitteeMembersQuery = `
+		SELECT COUNT(DISTINCT f_val_idx) AS f_count
+		FROM %s
+		WHERE f_period = (SELECT MAX(f_period) FROM %s)
+			AND f_val_idx IN (SELECT f_val_idx FROM %s)`
+)
+
+func syncCommitteeMembersInput(members []spec.SyncCommitteeMember) proto.Input {
+	// one object per column
+	var (
+		f_period     proto.ColUInt64
+		f_position   proto.ColUInt16
+		f_val_idx    proto.ColUInt64
+		f_public_key proto.ColStr
+	)
+
+	for _, member := range members {
+		f_period.Append(member.Period)
+		f_position.Append(uint16(member.Position))
+		f_val_idx.Append(uint64(member.ValIdx))
+		f_public_key.Append(member.PublicKey.String())
+	}
+
+	return proto.Input{
+		{Name: "f_period", Data: f_period},
+		{Name: "f_position", Data: f_position},
+		{Name: "f_val_idx", Data: f_val_idx},
+		{Name: "f_public_key", Data: f_public_key},
+	}
+}
+
+func (p *DBService) PersistSyncCommitteeMembers(data []spec.SyncCommitteeMember) error {
+	persistObj := PersistableObject[spec.SyncCommitteeMember]{
+		input: syncCommitteeMembersInput,
+		table: syncCommitteesTable,
+		query: insertSyncCommitteeMembersQuery,
+	}
+
+	for _, item := range data {
+		persistObj.Append(item)
+	}
+
+	err := bufferPersist(p, persistObj)
+	if err != nil {
+		log.Errorf("error persisting sync committee members: %s", err.Error())
+	}
+	return err
+}
+
+func (p *DBService) RetrieveTrackedSyncCommitteeMembers() (uint64, error) {
+
+	var dest []struct {
+		F_count uint64 `ch:"f_count"`
+	}
+
+	err := p.highSelect(
+		fmt.Sprintf(selectTrackedSyncCommitteeMembersQuery, syncCommitteesTable, syncCommitteesTable, eth2PubkeysTable),
+		&dest)
+
+	if len(dest) > 0 {
+		return dest[0].F_count, err
+	}
+	return 0, err
+}
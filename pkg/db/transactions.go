@@ -131,7 +131,7 @@ func (p *DBService) PersistTransactions(data []spec.AgnosticTransaction) error {
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting transactions: %s", err.Error())
 	}
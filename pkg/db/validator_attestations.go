@@ -0,0 +1,72 @@
+package db
+
+import (
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	valAttestationsTable             = "t_validator_attestations"
+	insertValidatorAttestationsQuery = `
+	INSERT INTO %s (
+		f_val_idx,
+		f_epoch,
+		f_att_slot,
+		f_committee_index,
+		f_inclusion_slot,
+		f_inclusion_delay)
+		VALUES`
+
+	deleteValidatorAttestationsQuery = `
+		DELETE FROM %s
+		WHERE f_epoch = $1;
+`
+)
+
+func validatorAttestationsInput(attestations []spec.ValidatorAttestation) proto.Input {
+	// one object per column
+	var (
+		f_val_idx         proto.ColUInt64
+		f_epoch           proto.ColUInt64
+		f_att_slot        proto.ColUInt64
+		f_committee_index proto.ColUInt64
+		f_inclusion_slot  proto.ColUInt64
+		f_inclusion_delay proto.ColUInt8
+	)
+
+	for _, attestation := range attestations {
+		f_val_idx.Append(uint64(attestation.ValIdx))
+		f_epoch.Append(uint64(attestation.AttSlot / spec.SlotsPerEpoch))
+		f_att_slot.Append(uint64(attestation.AttSlot))
+		f_committee_index.Append(uint64(attestation.CommitteeIndex))
+		f_inclusion_slot.Append(uint64(attestation.InclusionSlot))
+		f_inclusion_delay.Append(uint8(attestation.InclusionDelay))
+	}
+
+	return proto.Input{
+		{Name: "f_val_idx", Data: f_val_idx},
+		{Name: "f_epoch", Data: f_epoch},
+		{Name: "f_att_slot", Data: f_att_slot},
+		{Name: "f_committee_index", Data: f_committee_index},
+		{Name: "f_inclusion_slot", Data: f_inclusion_slot},
+		{Name: "f_inclusion_delay", Data: f_inclusion_delay},
+	}
+}
+
+func (p *DBService) PersistValidatorAttestations(data []spec.ValidatorAttestation) error {
+	persistObj := PersistableObject[spec.ValidatorAttestation]{
+		input: validatorAttestationsInput,
+		table: valAttestationsTable,
+		query: insertValidatorAttestationsQuery,
+	}
+
+	for _, item := range data {
+		persistObj.Append(item)
+	}
+
+	err := bufferPersist(p, persistObj)
+	if err != nil {
+		log.Errorf("error persisting validator attestations: %s", err.Error())
+	}
+	return err
+}
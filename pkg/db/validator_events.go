@@ -0,0 +1,60 @@
+package db
+
+import (
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	valEventsTable             = "t_validator_events"
+	insertValidatorEventsQuery = `
+	INSERT INTO %s (
+		f_val_idx,
+		f_epoch,
+		f_event)
+		VALUES`
+
+	deleteValidatorEventsQuery = `
+		DELETE FROM %s
+		WHERE f_epoch = $1;
+`
+)
+
+func valEventsInput(events []spec.ValidatorEvent) proto.Input {
+	// one object per column
+	var (
+		f_val_idx proto.ColUInt64
+		f_epoch   proto.ColUInt64
+		f_event   proto.ColUInt8
+	)
+
+	for _, event := range events {
+		f_val_idx.Append(uint64(event.ValIdx))
+		f_epoch.Append(uint64(event.Epoch))
+		f_event.Append(uint8(event.Event))
+	}
+
+	return proto.Input{
+		{Name: "f_val_idx", Data: f_val_idx},
+		{Name: "f_epoch", Data: f_epoch},
+		{Name: "f_event", Data: f_event},
+	}
+}
+
+func (p *DBService) PersistValidatorEvents(data []spec.ValidatorEvent) error {
+	persistObj := PersistableObject[spec.ValidatorEvent]{
+		input: valEventsInput,
+		table: valEventsTable,
+		query: insertValidatorEventsQuery,
+	}
+
+	for _, item := range data {
+		persistObj.Append(item)
+	}
+
+	err := bufferPersist(p, persistObj)
+	if err != nil {
+		log.Errorf("error persisting validator events: %s", err.Error())
+	}
+	return err
+}
@@ -18,7 +18,10 @@ var (
 		f_activation_epoch,
 		f_withdrawal_epoch,
 		f_exit_epoch,
-		f_public_key)
+		f_public_key,
+		f_withdrawal_credentials,
+		f_withdrawal_type,
+		f_withdrawal_address)
 	VALUES`
 
 	deleteValidatorStatus = `
@@ -29,15 +32,18 @@ var (
 func valStatusInput(validatorStatuses []spec.ValidatorLastStatus) proto.Input {
 	// one object per column
 	var (
-		f_val_idx          proto.ColUInt64
-		f_epoch            proto.ColUInt64
-		f_balance_eth      proto.ColFloat32
-		f_status           proto.ColUInt8
-		f_slashed          proto.ColBool
-		f_activation_epoch proto.ColUInt64
-		f_withdrawal_epoch proto.ColUInt64
-		f_exit_epoch       proto.ColUInt64
-		f_public_key       proto.ColStr
+		f_val_idx                proto.ColUInt64
+		f_epoch                  proto.ColUInt64
+		f_balance_eth            proto.ColFloat32
+		f_status                 proto.ColUInt8
+		f_slashed                proto.ColBool
+		f_activation_epoch       proto.ColUInt64
+		f_withdrawal_epoch       proto.ColUInt64
+		f_exit_epoch             proto.ColUInt64
+		f_public_key             proto.ColStr
+		f_withdrawal_credentials proto.ColStr
+		f_withdrawal_type        proto.ColUInt8
+		f_withdrawal_address     proto.ColStr
 	)
 
 	for _, status := range validatorStatuses {
@@ -51,6 +57,14 @@ func valStatusInput(validatorStatuses []spec.ValidatorLastStatus) proto.Input {
 		f_withdrawal_epoch.Append(uint64(status.WithdrawalEpoch))
 		f_exit_epoch.Append(uint64(status.ExitEpoch))
 		f_public_key.Append(status.PublicKey.String())
+		f_withdrawal_credentials.Append(status.WithdrawalCredentialsToHex())
+		f_withdrawal_type.Append(status.WithdrawalType())
+		address, ok := status.WithdrawalAddress()
+		if ok {
+			f_withdrawal_address.Append(address.String())
+		} else {
+			f_withdrawal_address.Append("")
+		}
 	}
 
 	return proto.Input{
@@ -64,6 +78,9 @@ func valStatusInput(validatorStatuses []spec.ValidatorLastStatus) proto.Input {
 		{Name: "f_withdrawal_epoch", Data: f_withdrawal_epoch},
 		{Name: "f_exit_epoch", Data: f_exit_epoch},
 		{Name: "f_public_key", Data: f_public_key},
+		{Name: "f_withdrawal_credentials", Data: f_withdrawal_credentials},
+		{Name: "f_withdrawal_type", Data: f_withdrawal_type},
+		{Name: "f_withdrawal_address", Data: f_withdrawal_address},
 	}
 }
 
@@ -78,7 +95,7 @@ func (p *DBService) PersistValLastStatus(data []spec.ValidatorLastStatus) error
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting validator last status: %s", err.Error())
 	}
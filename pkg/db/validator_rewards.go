@@ -27,7 +27,15 @@ var (
 		f_status,
 		f_block_api_reward,
 		f_block_experimental_reward,
-		f_inclusion_delay) VALUES`
+		f_inclusion_delay,
+		f_source_reward,
+		f_target_reward,
+		f_head_reward,
+		f_sync_reward,
+		f_proposer_reward,
+		f_slashing_reward,
+		f_inactivity_reward,
+		f_inactivity_score) VALUES`
 
 	deleteValidatorRewardsInEpochQuery = `
 		DELETE FROM %s
@@ -60,6 +68,14 @@ func rewardsInput(vals []spec.ValidatorRewards) proto.Input {
 		f_block_api_reward          proto.ColUInt64
 		f_block_experimental_reward proto.ColUInt64
 		f_inclusion_delay           proto.ColUInt8
+		f_source_reward             proto.ColInt64
+		f_target_reward             proto.ColInt64
+		f_head_reward               proto.ColInt64
+		f_sync_reward               proto.ColInt64
+		f_proposer_reward           proto.ColInt64
+		f_slashing_reward           proto.ColInt64
+		f_inactivity_reward         proto.ColInt64
+		f_inactivity_score          proto.ColUInt64
 	)
 
 	for _, val := range vals {
@@ -80,6 +96,14 @@ func rewardsInput(vals []spec.ValidatorRewards) proto.Input {
 		f_block_api_reward.Append(uint64(val.ProposerApiReward))
 		f_block_experimental_reward.Append(uint64(val.ProposerManualReward))
 		f_inclusion_delay.Append(uint8(val.InclusionDelay))
+		f_source_reward.Append(val.SourceReward)
+		f_target_reward.Append(val.TargetReward)
+		f_head_reward.Append(val.HeadReward)
+		f_sync_reward.Append(val.SyncReward)
+		f_proposer_reward.Append(val.ProposerReward)
+		f_slashing_reward.Append(val.SlashingReward)
+		f_inactivity_reward.Append(val.InactivityReward)
+		f_inactivity_score.Append(val.InactivityScore)
 	}
 
 	return proto.Input{
@@ -100,6 +124,14 @@ func rewardsInput(vals []spec.ValidatorRewards) proto.Input {
 		{Name: "f_block_api_reward", Data: f_block_api_reward},
 		{Name: "f_block_experimental_reward", Data: f_block_experimental_reward},
 		{Name: "f_inclusion_delay", Data: f_inclusion_delay},
+		{Name: "f_source_reward", Data: f_source_reward},
+		{Name: "f_target_reward", Data: f_target_reward},
+		{Name: "f_head_reward", Data: f_head_reward},
+		{Name: "f_sync_reward", Data: f_sync_reward},
+		{Name: "f_proposer_reward", Data: f_proposer_reward},
+		{Name: "f_slashing_reward", Data: f_slashing_reward},
+		{Name: "f_inactivity_reward", Data: f_inactivity_reward},
+		{Name: "f_inactivity_score", Data: f_inactivity_score},
 	}
 }
 
@@ -114,15 +146,27 @@ func (p *DBService) PersistValidatorRewards(data []spec.ValidatorRewards) error
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting validator rewards: %s", err.Error())
 	}
 	return err
 }
 
+// In ClickHouse retention is per partition: only the partitions whose epochs are all until the given one are dropped,
+// the partition that also holds later epochs is kept whole until the window leaves it, or the TTL drops its parts
 func (p *DBService) DeleteValidatorRewardsUntil(epoch phase0.Epoch) error {
 
+	if p.partitioned(valRewardsTable) {
+		dropped, err := p.DropPartitionsUntil(valRewardsTable, epoch)
+		if err != nil {
+			log.Errorf("error dropping validator rewards partitions: %s", err.Error())
+			return err
+		}
+		log.Debugf("dropped %d validator rewards partitions until epoch %d", dropped, epoch)
+		return nil
+	}
+
 	deleteObj := DeletableObject{
 		query: deleteValidatorRewardsUntilEpochQuery,
 		table: valRewardsTable,
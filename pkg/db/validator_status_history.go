@@ -0,0 +1,148 @@
+package db
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+// rows are only written when something changes, the valid-to epoch of each row
+// is derived from the next row of the same validator in v_validator_status_history
+var (
+	valStatusHistoryTable             = "t_validator_status_history"
+	insertValidatorStatusHistoryQuery = `
+	INSERT INTO %s (
+		f_val_idx,
+		f_valid_from,
+		f_status,
+		f_effective_balance_eth,
+		f_slashed,
+		f_withdrawal_credentials)
+		VALUES`
+
+	deleteValidatorStatusHistoryQuery = `
+		DELETE FROM %s
+		WHERE f_valid_from = $1;
+`
+)
+
+func valStatusHistoryInput(statuses []spec.ValidatorStatusHistory) proto.Input {
+	// one object per column
+	var (
+		f_val_idx                proto.ColUInt64
+		f_valid_from             proto.ColUInt64
+		f_status                 proto.ColUInt8
+		f_effective_balance_eth  proto.ColFloat32
+		f_slashed                proto.ColBool
+		f_withdrawal_credentials proto.ColStr
+	)
+
+	for _, status := range statuses {
+		f_val_idx.Append(uint64(status.ValIdx))
+		f_valid_from.Append(uint64(status.ValidFrom))
+		f_status.Append(uint8(status.Status))
+		f_effective_balance_eth.Append(status.EffectiveBalanceToEth())
+		f_slashed.Append(status.Slashed)
+		f_withdrawal_credentials.Append("0x" + hex.EncodeToString(status.WithdrawalCredentials))
+	}
+
+	return proto.Input{
+		{Name: "f_val_idx", Data: f_val_idx},
+		{Name: "f_valid_from", Data: f_valid_from},
+		{Name: "f_status", Data: f_status},
+		{Name: "f_effective_balance_eth", Data: f_effective_balance_eth},
+		{Name: "f_slashed", Data: f_slashed},
+		{Name: "f_withdrawal_credentials", Data: f_withdrawal_credentials},
+	}
+}
+
+func (p *DBService) PersistValidatorStatusHistory(data []spec.ValidatorStatusHistory) error {
+	persistObj := PersistableObject[spec.ValidatorStatusHistory]{
+		input: valStatusHistoryInput,
+		table: valStatusHistoryTable,
+		query: insertValidatorStatusHistoryQuery,
+	}
+
+	for _, item := range data {
+		persistObj.Append(item)
+	}
+
+	err := bufferPersist(p, persistObj)
+	if err != nil {
+		log.Errorf("error persisting validator status history: %s", err.Error())
+	}
+	return err
+}
+
+// Returns the last status recorded for every validator before the given epoch,
+// so that the first epoch processed after a restart only writes what changed since then
+func (p *DBService) RetrieveValidatorStatuses(epoch phase0.Epoch) (map[phase0.ValidatorIndex]spec.ValidatorStatusHistory, error) {
+	statuses := make(map[phase0.ValidatorIndex]spec.ValidatorStatusHistory)
+	if epoch == 0 {
+		return statuses, nil
+	}
+
+	// rows come ordered by f_valid_from, the last one of each validator is kept
+	err := p.StreamEpochs(valStatusHistoryTable, 0, epoch-1, func(_ phase0.Epoch, columns []Column, row []any) error {
+		status, err := valStatusHistoryRow(columns, row)
+		if err != nil {
+			return err
+		}
+		statuses[status.ValIdx] = status
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve validator statuses: %s", err)
+	}
+	return statuses, nil
+}
+
+// Undoes valStatusHistoryInput, the integer types of the row depend on the backend
+func valStatusHistoryRow(columns []Column, row []any) (spec.ValidatorStatusHistory, error) {
+	var status spec.ValidatorStatusHistory
+	for i, column := range columns {
+		value := reflect.ValueOf(row[i])
+		switch column.Name {
+		case "f_val_idx", "f_valid_from", "f_status":
+			var n uint64
+			switch value.Kind() {
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				n = value.Uint()
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				n = uint64(value.Int())
+			default:
+				return status, fmt.Errorf("column %s is not an integer: %T", column.Name, row[i])
+			}
+			switch column.Name {
+			case "f_val_idx":
+				status.ValIdx = phase0.ValidatorIndex(n)
+			case "f_valid_from":
+				status.ValidFrom = phase0.Epoch(n)
+			default:
+				status.Status = spec.ValidatorStatus(n)
+			}
+		case "f_effective_balance_eth":
+			if !value.CanFloat() {
+				return status, fmt.Errorf("column %s is not a float: %T", column.Name, row[i])
+			}
+			// effective balances are whole ETH
+			status.EffectiveBalance = phase0.Gwei(math.Round(value.Float())) * spec.EffectiveBalanceInc
+		case "f_slashed":
+			status.Slashed, _ = row[i].(bool)
+		case "f_withdrawal_credentials":
+			credentials, _ := row[i].(string)
+			decoded, err := hex.DecodeString(strings.TrimPrefix(credentials, "0x"))
+			if err != nil {
+				return status, fmt.Errorf("column %s: %s", column.Name, err)
+			}
+			status.WithdrawalCredentials = decoded
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+var (
+	versionColumn   = "f_version"      // time of the write in nanoseconds, the highest version of a key is kept
+	canonicalColumn = "f_is_canonical" // false for the rows of reorged slots and epochs
+
+	// copies the current canonical rows with the replaced columns and a higher version, so they replace the original ones
+	replaceCanonicalRowsQuery = `
+		INSERT INTO %[1]s
+		SELECT * REPLACE (%[2]s, %[3]d AS f_version)
+		FROM %[1]s FINAL
+		WHERE f_is_canonical AND (%[4]s)`
+
+	streamCanonicalEpochsQuery = `
+		SELECT * EXCEPT (f_version, f_is_canonical)
+		FROM %s FINAL
+		WHERE f_is_canonical AND %s >= $1 AND %s < $2
+		ORDER BY %s`
+)
+
+// Tables rewritten when a reorg changes the block of a slot or the state of an epoch.
+// In ClickHouse they are ReplacingMergeTree(f_version): a reorg inserts superseding rows instead of running DELETE mutations
+var versionedTables = map[string]bool{
+	blocksTable:                true,
+	transactionsTable:          true,
+	withdrawalsTable:           true,
+	voluntaryExitsTable:        true,
+	blsToExecutionChangesTable: true,
+	blobsTable:                 true,
+	attestationsTable:          true,
+	epochsTable:                true,
+	valAttestationsTable:       true,
+	valEventsTable:             true,
+	valStatusHistoryTable:      true,
+	depositsTable:              true,
+	syncParticipationTable:     true,
+	slashingsTable:             true,
+	epochFinalityTable:         true,
+	rewardsAuditTable:          true,
+	proposerDutiesTable:        true,
+	valRewardsTable:            true,
+}
+
+// Versions only grow, even when two writes happen in the same nanosecond
+type writeVersions struct {
+	last uint64
+	mu   sync.Mutex
+}
+
+func (v *writeVersions) next() uint64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	version := uint64(time.Now().UnixNano())
+	if version <= v.last {
+		version = v.last + 1
+	}
+	v.last = version
+	return version
+}
+
+// Only ClickHouse keeps versions, the other backends delete the reorged rows
+func (p *DBService) versioned(table string) bool {
+	return p.backend.name() == clickhouseScheme && versionedTables[table]
+}
+
+// Adds the version column to the insert of a versioned table
+func versionedInsert(query string, input proto.Input, version uint64) (string, proto.Input) {
+	var f_version proto.ColUInt64
+	for i := 0; i < inputRows(input); i++ {
+		f_version.Append(version)
+	}
+	return insertColumn(query, input, proto.InputColumn{Name: versionColumn, Data: f_version})
+}
+
+func inputRows(input proto.Input) int {
+	if len(input) == 0 {
+		return 0
+	}
+	return input[0].Data.Rows()
+}
+
+// Appends a column to the insert query and to a copy of the input, the original input could be spilled
+func insertColumn(query string, input proto.Input, column proto.InputColumn) (string, proto.Input) {
+	end := strings.LastIndex(query, ")")
+	if end < 0 {
+		return query, input
+	}
+
+	extendedInput := append(proto.Input{}, input...)
+	extendedInput = append(extendedInput, column)
+	return query[:end] + ",\n\t\t" + column.Name + query[end:], extendedInput
+}
+
+// Turns the delete of the reorged rows into the insert of their not canonical copies
+func supersedeFromDelete(obj DeletableObject, version uint64) (string, error) {
+	match := deleteRegex.FindStringSubmatch(obj.Query())
+	if match == nil {
+		return "", fmt.Errorf("could not parse delete query: %s", obj.Query())
+	}
+	// the rows written afterwards for the same keys have a higher version and replace the copies
+	return replaceCanonicalRows(match[1], "false AS "+canonicalColumn, version, match[2]), nil
+}
+
+// Rewrites the canonical rows of the table matched by the condition, the replace lists the new values of the columns
+func replaceCanonicalRows(table string, replace string, version uint64, condition string) string {
+	return fmt.Sprintf(replaceCanonicalRowsQuery, table, replace, version, condition)
+}
+
+// Marks the rows matched by the delete as not canonical, the rows written afterwards for the same slot or epoch replace them.
+// Tables that are not versioned, or backends without versions, delete the rows.
+func (p *DBService) Supersede(obj DeletableObject) error {
+	if !p.versioned(obj.table) {
+		return p.Delete(obj)
+	}
+
+	var err error
+	startTime := time.Now()
+
+	err = p.persistPending(obj.table)
+	if err != nil {
+		return err
+	}
+
+	query, err := supersedeFromDelete(obj, p.versions.next())
+	if err != nil {
+		return err
+	}
+
+	p.highMu.Lock()
+	err = p.backend.exec(p.ctx, query, obj.Args()...)
+	p.highMu.Unlock()
+
+	if err == nil {
+		log.Infof("superseded rows of %s where %v in %f seconds", obj.table, obj.Args(), time.Since(startTime).Seconds())
+		p.publishDelete(obj)
+	}
+
+	return err
+}
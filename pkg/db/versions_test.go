@@ -0,0 +1,84 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+func TestVersionedInsert(t *testing.T) {
+	task := testSpillTask()
+
+	query, input := versionedInsert(task.query, task.input, 42)
+
+	if !strings.HasSuffix(strings.TrimSpace(query), "f_version)\n\t\tVALUES") {
+		t.Errorf("version column not added to the insert: %s", query)
+	}
+	if len(input) != len(task.input)+1 {
+		t.Fatalf("expected %d columns, got %d", len(task.input)+1, len(input))
+	}
+	version := input[len(input)-1]
+	if version.Name != versionColumn || version.Data.Rows() != task.rows {
+		t.Errorf("expected %d rows of %s, got %d rows of %s", task.rows, versionColumn, version.Data.Rows(), version.Name)
+	}
+	if version.Data.(proto.ColUInt64).Row(1) != 42 {
+		t.Errorf("expected version 42, got %d", version.Data.(proto.ColUInt64).Row(1))
+	}
+	// the original input is not modified, it could be spilled
+	if len(task.input) != len(testSpillTask().input) {
+		t.Errorf("original input was modified")
+	}
+}
+
+func TestSupersedeFromDelete(t *testing.T) {
+	query, err := supersedeFromDelete(DeletableObject{
+		query: deleteProposerDutiesQuery,
+		table: proposerDutiesTable,
+		args:  []any{phase0.Slot(2) * spec.SlotsPerEpoch, phase0.Slot(3) * spec.SlotsPerEpoch},
+	}, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+		INSERT INTO t_proposer_duties
+		SELECT * REPLACE (false AS f_is_canonical, 7 AS f_version)
+		FROM t_proposer_duties FINAL
+		WHERE f_is_canonical AND (f_proposer_slot >= $1 AND f_proposer_slot < $2)`
+	if query != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, query)
+	}
+
+	_, err = supersedeFromDelete(DeletableObject{query: "TRUNCATE %s", table: blocksTable}, 7)
+	if err == nil {
+		t.Errorf("expected an error for a query that is not a delete")
+	}
+}
+
+func TestWriteVersions(t *testing.T) {
+	var versions writeVersions
+
+	last := versions.next()
+	for i := 0; i < 1000; i++ {
+		version := versions.next()
+		if version <= last {
+			t.Fatalf("version %d is not higher than %d", version, last)
+		}
+		last = version
+	}
+}
+
+func TestVersionedTables(t *testing.T) {
+	store := newTestMemoryStore(t, "")
+
+	// the memory backend deletes the reorged rows
+	if store.versioned(blocksTable) {
+		t.Errorf("memory backend should not version %s", blocksTable)
+	}
+	if strings.Contains(insertPoolSummaryPostgres, "FINAL") || strings.Contains(insertPoolSummaryPostgres, canonicalColumn) {
+		t.Errorf("postgres pool summary uses versioned columns: %s", insertPoolSummaryPostgres)
+	}
+}
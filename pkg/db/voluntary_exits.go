@@ -0,0 +1,59 @@
+package db
+
+import (
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+var (
+	voluntaryExitsTable       = "t_voluntary_exits"
+	insertVoluntaryExitsQuery = `
+	INSERT INTO %s (
+		f_val_idx,
+		f_epoch,
+		f_slot)
+		VALUES`
+
+	deleteVoluntaryExitsQuery = `
+		DELETE FROM %s
+		WHERE f_slot = $1;`
+)
+
+func voluntaryExitsInput(exits []spec.VoluntaryExit) proto.Input {
+	// one object per column
+	var (
+		f_val_idx proto.ColUInt64
+		f_epoch   proto.ColUInt64
+		f_slot    proto.ColUInt64
+	)
+
+	for _, exit := range exits {
+		f_val_idx.Append(uint64(exit.ValIdx))
+		f_epoch.Append(uint64(exit.Epoch))
+		f_slot.Append(uint64(exit.Slot))
+	}
+
+	return proto.Input{
+		{Name: "f_val_idx", Data: f_val_idx},
+		{Name: "f_epoch", Data: f_epoch},
+		{Name: "f_slot", Data: f_slot},
+	}
+}
+
+func (p *DBService) PersistVoluntaryExits(data []spec.VoluntaryExit) error {
+	persistObj := PersistableObject[spec.VoluntaryExit]{
+		input: voluntaryExitsInput,
+		table: voluntaryExitsTable,
+		query: insertVoluntaryExitsQuery,
+	}
+
+	for _, item := range data {
+		persistObj.Append(item)
+	}
+
+	err := bufferPersist(p, persistObj)
+	if err != nil {
+		log.Errorf("error persisting voluntary exits: %s", err.Error())
+	}
+	return err
+}
@@ -61,7 +61,7 @@ func (p *DBService) PersistWithdrawals(data []spec.Withdrawal) error {
 		persistObj.Append(item)
 	}
 
-	err := p.Persist(persistObj.ExportPersist())
+	err := bufferPersist(p, persistObj)
 	if err != nil {
 		log.Errorf("error persisting withdrawals: %s", err.Error())
 	}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+var (
+	defaultWriterNum = 1
+	writeQueueSize   = 64 // batches waiting for a writer, persisting blocks once it is full
+)
+
+// Batch of rows waiting in the write queue
+type writeTask struct {
+	query string
+	table string
+	input proto.Input
+	rows  int
+}
+
+func WithDBWorkers(workers int) DBServiceOption {
+	return func(s *DBService) error {
+		if workers < 1 {
+			return fmt.Errorf("at least one db worker is needed, %d were given", workers)
+		}
+		s.writerNum = workers
+		return nil
+	}
+}
+
+// Opens one connection per writer and starts consuming the write queue
+func (s *DBService) startWriters() error {
+	for i := 0; i < s.writerNum; i++ {
+		writer, err := s.backend.newWriter(s.ctx)
+		if err != nil {
+			return fmt.Errorf("could not open connection for db writer %d: %s", i, err)
+		}
+		s.writers = append(s.writers, writer)
+	}
+
+	for i := range s.writers {
+		s.writersWg.Add(1)
+		go s.runWriter(i)
+	}
+	log.Infof("%d db writers started", len(s.writers))
+	return nil
+}
+
+func (s *DBService) runWriter(i int) {
+	defer s.writersWg.Done()
+
+	for task := range s.writeQueue {
+		err := s.write(s.writers[i], task)
+		if err != nil {
+			log.Errorf("error persisting %d rows to %s: %s", task.rows, task.table, err.Error())
+			s.spill(task)
+			s.reconnectWriter(i)
+		}
+		s.donePending()
+	}
+}
+
+// Replaces the connection of the writer when the failed insert closed it
+func (s *DBService) reconnectWriter(i int) {
+	if !s.writers[i].isClosed() {
+		return
+	}
+
+	writer, err := s.backend.newWriter(s.ctx)
+	if err != nil {
+		log.Warnf("could not reconnect db writer %d: %s", i, err)
+		return
+	}
+	s.writers[i] = writer
+	log.Infof("db writer %d reconnected", i)
+}
+
+func (s *DBService) write(writer dbWriter, task writeTask) error {
+	startTime := time.Now()
+
+	err := writer.insert(s.ctx, task)
+	elapsedTime := time.Since(startTime)
+
+	if err == nil {
+		log.Debugf("table %s persisted %d rows in %fs", task.table, task.rows, elapsedTime.Seconds())
+
+		s.metricsMu.Lock()
+		s.monitorMetrics[task.table].addNewPersist(task.rows, elapsedTime)
+		s.metricsMu.Unlock()
+
+		s.publish(task)
+	}
+	return err
+}
+
+// Stops the writers once the queue is empty
+func (s *DBService) stopWriters() {
+	s.writeMu.Lock()
+	s.writersClosed = true
+	close(s.writeQueue)
+	s.writeMu.Unlock()
+
+	s.writersWg.Wait()
+	for _, writer := range s.writers {
+		writer.close()
+	}
+}
+
+func (s *DBService) addPending() {
+	s.pendingMu.Lock()
+	s.pendingWrites++
+	s.pendingMu.Unlock()
+}
+
+func (s *DBService) donePending() {
+	s.pendingMu.Lock()
+	s.pendingWrites--
+	if s.pendingWrites == 0 {
+		s.pendingCond.Broadcast()
+	}
+	s.pendingMu.Unlock()
+}
+
+// Blocks until every queued batch has been written
+func (s *DBService) waitWrites() {
+	s.pendingMu.Lock()
+	for s.pendingWrites > 0 {
+		s.pendingCond.Wait()
+	}
+	s.pendingMu.Unlock()
+}
+
+func (s *DBService) WriteQueueDepth() int {
+	return len(s.writeQueue)
+}
@@ -0,0 +1,208 @@
+package db
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Backend whose writers block every insert until released, recording the batches in the order they are written
+type gateBackend struct {
+	*memoryBackend
+	release chan struct{}
+	mu      sync.Mutex
+	written []int // first slot of every written batch
+}
+
+type gateWriter struct {
+	backend *gateBackend
+}
+
+func (b *gateBackend) newWriter(ctx context.Context) (dbWriter, error) {
+	return &gateWriter{backend: b}, nil
+}
+
+func (b *gateBackend) writtenBatches() []int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]int{}, b.written...)
+}
+
+func (w *gateWriter) insert(ctx context.Context, task writeTask) error {
+	<-w.backend.release
+	rows, err := taskRows(task)
+	if err != nil {
+		return err
+	}
+	w.backend.mu.Lock()
+	w.backend.written = append(w.backend.written, int(rows[0]["f_slot"].(uint64)))
+	w.backend.mu.Unlock()
+	return nil
+}
+
+func (w *gateWriter) isClosed() bool {
+	return false
+}
+
+func (w *gateWriter) close() {}
+
+func newGateService(t *testing.T, queueSize int, writers int) (*DBService, *gateBackend) {
+	defer func(size int) { writeQueueSize = size }(writeQueueSize)
+	writeQueueSize = queueSize
+
+	service, err := New(context.Background(), memoryScheme+"://", WithDBWorkers(writers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &gateBackend{
+		memoryBackend: newMemoryBackend(memoryScheme + "://"),
+		release:       make(chan struct{}),
+	}
+	service.backend = backend
+
+	err = service.startWriters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service, backend
+}
+
+// Batch of block rewards whose first slot identifies it
+func persistTestBatch(t *testing.T, service *DBService, slot int) {
+	task := testSpillTask()
+	rewards := []BlockReward{{Slot: phase0.Slot(slot)}}
+	err := service.Persist(task.query, task.table, blockRewardsInput(rewards), len(rewards))
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+// Returns a channel closed once fn returns
+func runAsync(fn func()) chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+	return done
+}
+
+func returned(done chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	case <-time.After(50 * time.Millisecond):
+		return false
+	}
+}
+
+func TestWriteQueueBackpressure(t *testing.T) {
+	service, backend := newGateService(t, 1, 1)
+
+	persistTestBatch(t, service, 1) // taken by the writer, blocked inserting it
+	time.Sleep(10 * time.Millisecond)
+	persistTestBatch(t, service, 2) // waits in the queue
+
+	// the queue is full, persisting blocks until the writer is released
+	done := runAsync(func() { persistTestBatch(t, service, 3) })
+	if returned(done) {
+		t.Fatal("persist did not block with a full write queue")
+	}
+
+	close(backend.release)
+	if !returned(done) {
+		t.Fatal("persist still blocked after the writer was released")
+	}
+	service.stopWriters()
+}
+
+func TestWriteQueueOrder(t *testing.T) {
+	service, backend := newGateService(t, 8, 1)
+	close(backend.release)
+
+	for slot := 1; slot <= 8; slot++ {
+		persistTestBatch(t, service, slot)
+	}
+	service.waitWrites()
+
+	written := backend.writtenBatches()
+	if len(written) != 8 {
+		t.Fatalf("expected 8 batches, got %d", len(written))
+	}
+	for i, slot := range written {
+		if slot != i+1 {
+			t.Fatalf("a single writer inserts the batches in order, got %v", written)
+		}
+	}
+	service.stopWriters()
+}
+
+func TestWaitWrites(t *testing.T) {
+	service, backend := newGateService(t, 4, 2)
+
+	persistTestBatch(t, service, 1)
+	persistTestBatch(t, service, 2)
+	persistTestBatch(t, service, 3)
+
+	done := runAsync(service.waitWrites)
+	if returned(done) {
+		t.Fatal("waitWrites returned with pending batches")
+	}
+
+	close(backend.release)
+	if !returned(done) {
+		t.Fatal("waitWrites did not return once the batches were written")
+	}
+	if written := backend.writtenBatches(); len(written) != 3 {
+		t.Errorf("expected 3 written batches, got %v", written)
+	}
+	service.stopWriters()
+}
+
+func TestPersistAfterStop(t *testing.T) {
+	service, backend := newGateService(t, 4, 1)
+	close(backend.release)
+	service.spillDir = t.TempDir()
+	service.stopWriters()
+
+	task := testSpillTask()
+	err := service.Persist(task.query, task.table, task.input, task.rows)
+	if err == nil {
+		t.Error("expected an error once the writers are closed")
+	}
+
+	// the rows are not lost, they are replayed on the next run
+	if service.PendingSpilledRows() != int64(task.rows) {
+		t.Errorf("expected %d spilled rows, got %d", task.rows, service.PendingSpilledRows())
+	}
+}
+
+func TestConnectReadOnly(t *testing.T) {
+	service, err := New(context.Background(), memoryScheme+"://")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = service.ConnectReadOnly()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer service.Finish()
+
+	if len(service.writers) != 0 {
+		t.Errorf("expected no db writers, %d were started", len(service.writers))
+	}
+
+	task := testSpillTask()
+	done := runAsync(func() {
+		err = service.Persist(task.query, task.table, task.input, task.rows)
+	})
+	if !returned(done) {
+		t.Fatal("persist blocked on a read only connection")
+	}
+	if err == nil {
+		t.Error("expected an error persisting on a read only connection")
+	}
+}
@@ -0,0 +1,158 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/config"
+	"github.com/migalabs/goteth/pkg/db"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	log = logrus.WithField(
+		"module", "export",
+	)
+)
+
+type Exporter struct {
+	ctx           context.Context
+	dbClient      *db.DBService // database the tables are streamed from
+	tables        []string
+	fromEpoch     phase0.Epoch
+	toEpoch       phase0.Epoch // 0 exports up to the last epoch in the database
+	format        string       // parquet or csv
+	outDir        string
+	epochsPerFile phase0.Epoch // each file holds the rows of this many epochs
+}
+
+func NewExporter(
+	pCtx context.Context,
+	iConfig config.ExportConfig) (*Exporter, error) {
+
+	if iConfig.EpochsPerFile <= 0 {
+		return nil, fmt.Errorf("epochs per file must be positive, got %d", iConfig.EpochsPerFile)
+	}
+	if _, ok := fileExtensions[iConfig.Format]; !ok {
+		return nil, fmt.Errorf("unsupported format %s, use parquet or csv", iConfig.Format)
+	}
+
+	var tables []string
+	for _, table := range strings.Split(iConfig.Tables, ",") {
+		table = strings.TrimSpace(table)
+		if table != "" {
+			tables = append(tables, table)
+		}
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no tables to export, available tables: %s", strings.Join(db.StreamableTables(), ","))
+	}
+
+	// database
+	idbClient, err := db.New(pCtx, iConfig.DBUrl)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to generate DB Client.")
+	}
+	// the tables are only read, they are not migrated nor written
+	err = idbClient.ConnectReadOnly()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to connect DB Client.")
+	}
+
+	return &Exporter{
+		ctx:           pCtx,
+		dbClient:      idbClient,
+		tables:        tables,
+		fromEpoch:     iConfig.FromEpoch,
+		toEpoch:       iConfig.ToEpoch,
+		format:        iConfig.Format,
+		outDir:        iConfig.OutDir,
+		epochsPerFile: phase0.Epoch(iConfig.EpochsPerFile),
+	}, nil
+}
+
+// Exports every table, the files of each table go into <out>/<table>
+func (s *Exporter) Run() error {
+	toEpoch := s.toEpoch
+	if toEpoch == 0 {
+		lastEpoch, err := s.dbClient.RetrieveLastEpoch()
+		if err != nil {
+			return fmt.Errorf("could not retrieve the last epoch in the database: %s", err)
+		}
+		toEpoch = lastEpoch
+	}
+	if toEpoch < s.fromEpoch {
+		return fmt.Errorf("to-epoch %d is lower than from-epoch %d", toEpoch, s.fromEpoch)
+	}
+
+	for _, table := range s.tables {
+		if s.ctx.Err() != nil {
+			return s.ctx.Err()
+		}
+		log.Infof("exporting %s from epoch %d to %d", table, s.fromEpoch, toEpoch)
+
+		rows, files, err := s.exportTable(table, toEpoch)
+		if err != nil {
+			return fmt.Errorf("could not export %s: %s", table, err)
+		}
+		log.Infof("exported %d rows of %s into %d files", rows, table, files)
+	}
+	return nil
+}
+
+// Streams the rows of the table into one file per epochsPerFile epochs, files are aligned to multiples of epochsPerFile
+func (s *Exporter) exportTable(table string, toEpoch phase0.Epoch) (int, int, error) {
+	dir := filepath.Join(s.outDir, table)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		file      tableFile
+		fileStart phase0.Epoch
+		rows      int
+		files     int
+	)
+
+	err = s.dbClient.StreamEpochs(table, s.fromEpoch, toEpoch, func(epoch phase0.Epoch, columns []db.Column, row []any) error {
+		start := epoch / s.epochsPerFile * s.epochsPerFile
+		if file == nil || start != fileStart {
+			if file != nil {
+				err := file.close()
+				if err != nil {
+					return err
+				}
+			}
+
+			path := filepath.Join(dir, fmt.Sprintf("%s_%d-%d.%s", table, start, start+s.epochsPerFile-1, fileExtensions[s.format]))
+			newFile, err := newTableFile(s.format, path, table, columns)
+			if err != nil {
+				return err
+			}
+			file = newFile
+			fileStart = start
+			files++
+		}
+
+		rows++
+		return file.write(row)
+	})
+
+	if file != nil {
+		closeErr := file.close()
+		if err == nil {
+			err = closeErr
+		}
+	}
+	return rows, files, err
+}
+
+func (s *Exporter) Close() {
+	s.dbClient.Finish()
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/db"
+	"github.com/migalabs/goteth/pkg/spec"
+	"github.com/parquet-go/parquet-go"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/assert"
+)
+
+// Memory store with one block and one block reward in the first slot of epochs 0 to 5
+func newTestExporter(t *testing.T, format string) *Exporter {
+	store, err := db.NewMemoryStore(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(store.Finish)
+
+	var blocks []spec.AgnosticBlock
+	var rewards []db.BlockReward
+	for epoch := phase0.Epoch(0); epoch <= 5; epoch++ {
+		slot := phase0.Slot(epoch) * spec.SlotsPerEpoch
+		blocks = append(blocks, spec.AgnosticBlock{
+			Slot:          slot,
+			ProposerIndex: phase0.ValidatorIndex(epoch),
+			Proposed:      true,
+			SyncAggregate: &altair.SyncAggregate{SyncCommitteeBits: bitfield.NewBitvector512()},
+		})
+		rewards = append(rewards, db.BlockReward{Slot: slot, Relays: []string{"relay-a", "relay,b"}})
+	}
+	assert.NoError(t, store.PersistBlocks(blocks))
+	assert.NoError(t, store.PersistBlockRewards(rewards))
+
+	return &Exporter{
+		ctx:           context.Background(),
+		dbClient:      store.DBService,
+		tables:        []string{"t_block_metrics", "t_block_rewards"},
+		fromEpoch:     1,
+		toEpoch:       4,
+		format:        format,
+		outDir:        t.TempDir(),
+		epochsPerFile: 2,
+	}
+}
+
+func TestExportParquet(t *testing.T) {
+	s := newTestExporter(t, parquetFormat)
+	assert.NoError(t, s.Run())
+
+	// epochs 1 to 4 in files aligned to 2 epochs
+	files, _ := filepath.Glob(filepath.Join(s.outDir, "t_block_metrics", "*.parquet"))
+	assert.Equal(t, []string{
+		filepath.Join(s.outDir, "t_block_metrics", "t_block_metrics_0-1.parquet"),
+		filepath.Join(s.outDir, "t_block_metrics", "t_block_metrics_2-3.parquet"),
+		filepath.Join(s.outDir, "t_block_metrics", "t_block_metrics_4-5.parquet"),
+	}, files)
+
+	f, err := os.Open(files[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	reader := parquet.NewReader(f)
+	assert.Equal(t, int64(2), reader.NumRows())
+
+	slot, _ := reader.Schema().Lookup("f_slot")
+	assert.Equal(t, parquet.Uint(64).Type(), slot.Node.Type())
+
+	for _, epoch := range []uint64{2, 3} {
+		row := map[string]any{}
+		assert.NoError(t, reader.Read(&row))
+		assert.EqualValues(t, epoch, row["f_epoch"])
+	}
+
+	// arrays are typed lists
+	rewards, _ := filepath.Glob(filepath.Join(s.outDir, "t_block_rewards", "*.parquet"))
+	assert.Len(t, rewards, 3)
+	rf, err := os.Open(rewards[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Close()
+	var reward struct {
+		Slot   uint64   `parquet:"f_slot"`
+		Relays []string `parquet:"f_relays,list"`
+	}
+	assert.NoError(t, parquet.NewReader(rf).Read(&reward))
+	assert.Equal(t, uint64(16), reward.Slot)
+	assert.Equal(t, []string{"relay-a", "relay,b"}, reward.Relays)
+}
+
+func TestExportCSV(t *testing.T) {
+	s := newTestExporter(t, csvFormat)
+	assert.NoError(t, s.Run())
+
+	f, err := os.Open(filepath.Join(s.outDir, "t_block_rewards", "t_block_rewards_4-5.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// header and the reward of epoch 4, epoch 5 is out of the range
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, "f_slot", records[0][0])
+		assert.Equal(t, "64", records[1][0])
+		assert.Contains(t, records[1], `["relay-a","relay,b"]`)
+	}
+}
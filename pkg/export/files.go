@@ -0,0 +1,173 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/migalabs/goteth/pkg/db"
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress/zstd"
+)
+
+var (
+	parquetFormat = "parquet"
+	csvFormat     = "csv"
+
+	fileExtensions = map[string]string{
+		parquetFormat: "parquet",
+		csvFormat:     "csv",
+	}
+)
+
+// File receiving the rows of a table, in the order of the columns it was created with
+type tableFile interface {
+	write(row []any) error
+	close() error
+}
+
+func newTableFile(format string, path string, table string, columns []db.Column) (tableFile, error) {
+	switch format {
+	case parquetFormat:
+		return newParquetFile(path, table, columns)
+	case csvFormat:
+		return newCSVFile(path, columns)
+	default:
+		return nil, fmt.Errorf("unsupported format %s", format)
+	}
+}
+
+// Parquet node of each ClickHouse type, unsigned columns keep their unsigned logical type
+var parquetNodes = map[string]parquet.Node{
+	"UInt64":        parquet.Uint(64),
+	"UInt32":        parquet.Uint(32),
+	"UInt16":        parquet.Uint(16),
+	"UInt8":         parquet.Uint(8),
+	"Int64":         parquet.Int(64),
+	"Int32":         parquet.Int(32),
+	"Int16":         parquet.Int(16),
+	"Int8":          parquet.Int(8),
+	"Float32":       parquet.Leaf(parquet.FloatType),
+	"Float64":       parquet.Leaf(parquet.DoubleType),
+	"Bool":          parquet.Leaf(parquet.BooleanType),
+	"String":        parquet.String(),
+	"Array(String)": parquet.List(parquet.String()),
+}
+
+func parquetSchema(table string, columns []db.Column) (*parquet.Schema, error) {
+	group := parquet.Group{}
+	for _, column := range columns {
+		node, ok := parquetNodes[column.Type]
+		if !ok {
+			return nil, fmt.Errorf("column %s has unsupported type %s", column.Name, column.Type)
+		}
+		group[column.Name] = node
+	}
+	return parquet.NewSchema(table, group), nil
+}
+
+type parquetFile struct {
+	f       *os.File
+	w       *parquet.Writer
+	columns []db.Column
+}
+
+func newParquetFile(path string, table string, columns []db.Column) (*parquetFile, error) {
+	schema, err := parquetSchema(table, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetFile{
+		f:       f,
+		w:       parquet.NewWriter(f, schema, parquet.Compression(&zstd.Codec{})),
+		columns: columns,
+	}, nil
+}
+
+func (p *parquetFile) write(row []any) error {
+	record := make(map[string]any, len(p.columns))
+	for i, column := range p.columns {
+		record[column.Name] = row[i]
+	}
+	return p.w.Write(record)
+}
+
+func (p *parquetFile) close() error {
+	err := p.w.Close()
+	if err != nil {
+		p.f.Close()
+		return err
+	}
+	return p.f.Close()
+}
+
+// CSV with a header, arrays are written as JSON
+type csvFile struct {
+	f *os.File
+	b *bufio.Writer
+	w *csv.Writer
+}
+
+func newCSVFile(path string, columns []db.Column) (*csvFile, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	b := bufio.NewWriter(f)
+	w := csv.NewWriter(b)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+	}
+	err = w.Write(header)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &csvFile{f: f, b: b, w: w}, nil
+}
+
+func (c *csvFile) write(row []any) error {
+	record := make([]string, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case string:
+			record[i] = v
+		case float32:
+			record[i] = strconv.FormatFloat(float64(v), 'f', -1, 32)
+		case float64:
+			record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case []string:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			record[i] = string(encoded)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvFile) close() error {
+	c.w.Flush()
+	err := c.w.Error()
+	if err == nil {
+		err = c.b.Flush()
+	}
+	if err != nil {
+		c.f.Close()
+		return err
+	}
+	return c.f.Close()
+}
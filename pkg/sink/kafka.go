@@ -0,0 +1,210 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/migalabs/goteth/pkg/db"
+	"github.com/sirupsen/logrus"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	log = logrus.WithField(
+		"module", "sink",
+	)
+
+	JSONFormat     = "json"
+	ProtobufFormat = "protobuf"
+
+	// headers of every message
+	tableHeader = "goteth-table"
+	opHeader    = "goteth-op"    // insert or delete
+	whereHeader = "goteth-where" // conditions of the deleted rows, in tombstones only
+
+	insertOp = "insert"
+	deleteOp = "delete"
+
+	maxExactFloat = uint64(1) << 53 // integers above it lose precision as protobuf numbers
+
+	flushTimeout       = 30 * time.Second // max wait for the pending messages when closing
+	deliveryTimeout    = time.Minute      // messages not acknowledged by then are dropped
+	maxBufferedRecords = 100_000          // messages waiting to be sent, new ones are dropped once it is full
+)
+
+// Publishes one message per persisted row to <topic prefix>.<table>
+// Messages are keyed by the slot or epoch of the row, so that every row of a slot stays in order in one partition.
+// Deletes are published as tombstones: a message with the key of the deleted slot or epoch and no value.
+// Keys are shared by every row of the slot or epoch, so topics must use cleanup.policy=delete:
+// a compacted topic would only keep the last row of each key and drop all of them on a tombstone.
+// Publishing never blocks the db writers: while the brokers are unreachable messages are dropped and counted.
+type KafkaSink struct {
+	ctx         context.Context
+	client      *kgo.Client
+	topicPrefix string
+	format      string // json or protobuf
+	dropped     uint64 // messages that could not be buffered or delivered
+}
+
+func NewKafkaSink(ctx context.Context, brokers []string, topicPrefix string, format string) (*KafkaSink, error) {
+	if format != JSONFormat && format != ProtobufFormat {
+		return nil, fmt.Errorf("unsupported sink format %s, use %s or %s", format, JSONFormat, ProtobufFormat)
+	}
+
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(brokers...),
+		kgo.AllowAutoTopicCreation(),
+		kgo.MaxBufferedRecords(maxBufferedRecords),
+		kgo.RecordDeliveryTimeout(deliveryTimeout),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("could not reach the brokers %s: %s", strings.Join(brokers, ","), err)
+	}
+
+	log.Infof("publishing persisted rows to %s as %s", strings.Join(brokers, ","), format)
+	return &KafkaSink{
+		ctx:         ctx,
+		client:      client,
+		topicPrefix: topicPrefix,
+		format:      format,
+	}, nil
+}
+
+func (k *KafkaSink) Topic(table string) string {
+	return fmt.Sprintf("%s.%s", k.topicPrefix, table)
+}
+
+func (k *KafkaSink) Publish(table string, rows []db.Row) error {
+	keyColumn, hasKey := db.KeyColumn(table)
+
+	for _, row := range rows {
+		value, err := k.encode(row)
+		if err != nil {
+			return err
+		}
+
+		record := &kgo.Record{
+			Topic: k.Topic(table),
+			Value: value,
+			Headers: []kgo.RecordHeader{
+				{Key: tableHeader, Value: []byte(table)},
+				{Key: opHeader, Value: []byte(insertOp)},
+			},
+		}
+		if hasKey {
+			record.Key = []byte(fmt.Sprint(row[keyColumn]))
+		}
+		k.produce(record)
+	}
+	return nil
+}
+
+func (k *KafkaSink) PublishDelete(table string, conditions []db.Condition) error {
+	keyColumn, _ := db.KeyColumn(table)
+
+	var key []byte
+	where := make([]string, len(conditions))
+	for i, condition := range conditions {
+		where[i] = condition.String()
+		if condition.Column == keyColumn && key == nil {
+			key = []byte(strconv.FormatUint(condition.Value, 10))
+		}
+	}
+
+	k.produce(&kgo.Record{
+		Topic: k.Topic(table),
+		Key:   key,
+		Value: nil,
+		Headers: []kgo.RecordHeader{
+			{Key: tableHeader, Value: []byte(table)},
+			{Key: opHeader, Value: []byte(deleteOp)},
+			{Key: whereHeader, Value: []byte(strings.Join(where, " AND "))},
+		},
+	})
+	return nil
+}
+
+// Messages are sent in the background, they are dropped when the producer buffer is full
+func (k *KafkaSink) produce(record *kgo.Record) {
+	k.client.TryProduce(k.ctx, record, func(r *kgo.Record, err error) {
+		if err == nil {
+			return
+		}
+		dropped := atomic.AddUint64(&k.dropped, 1)
+		if errors.Is(err, kgo.ErrMaxBuffered) {
+			if dropped%uint64(maxBufferedRecords) == 1 { // once per buffer of dropped messages
+				log.Warnf("sink buffer is full, dropping messages: %d dropped so far", dropped)
+			}
+			return
+		}
+		log.Errorf("could not publish message to %s: %s", r.Topic, err)
+	})
+}
+
+// Messages that were not published since the sink started
+func (k *KafkaSink) DroppedMessages() uint64 {
+	return atomic.LoadUint64(&k.dropped)
+}
+
+func (k *KafkaSink) encode(row db.Row) ([]byte, error) {
+	if k.format == JSONFormat {
+		return json.Marshal(row)
+	}
+
+	// google.protobuf.Struct, values have to be the types it knows
+	fields := make(map[string]any, len(row))
+	for column, value := range row {
+		switch v := value.(type) {
+		case uint8:
+			fields[column] = uint32(v)
+		case uint16:
+			fields[column] = uint32(v)
+		case uint64:
+			if v > maxExactFloat {
+				fields[column] = strconv.FormatUint(v, 10)
+			} else {
+				fields[column] = v
+			}
+		case []string:
+			items := make([]any, len(v))
+			for i := range v {
+				items[i] = v[i]
+			}
+			fields[column] = items
+		default:
+			fields[column] = value
+		}
+	}
+
+	msg, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+// Waits until every message was sent
+func (k *KafkaSink) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	err := k.client.Flush(ctx)
+	if err != nil {
+		log.Errorf("could not flush the sink: %s", err)
+	}
+	k.client.Close()
+}
@@ -0,0 +1,206 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/migalabs/goteth/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// In-process broker standing in for Kafka
+func newTestBroker(t *testing.T) []string {
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.AllowAutoTopicCreation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cluster.Close)
+	return cluster.ListenAddrs()
+}
+
+func consume(t *testing.T, brokers []string, topic string, n int) []*kgo.Record {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(brokers...),
+		kgo.ConsumeTopics(topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var records []*kgo.Record
+	for len(records) < n {
+		fetches := client.PollFetches(ctx)
+		if ctx.Err() != nil {
+			t.Fatalf("expected %d messages in %s, got %d", n, topic, len(records))
+		}
+		records = append(records, fetches.Records()...)
+	}
+	return records
+}
+
+func header(record *kgo.Record, key string) string {
+	for _, h := range record.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+var testRows = []db.Row{
+	{"f_slot": uint64(32), "f_epoch": uint64(2), "f_proposed": true, "f_graffiti": "goteth"},
+	{"f_slot": uint64(33), "f_epoch": uint64(2), "f_proposed": false, "f_graffiti": ""},
+}
+
+func TestKafkaSinkJSON(t *testing.T) {
+	brokers := newTestBroker(t)
+	sink, err := NewKafkaSink(context.Background(), brokers, "goteth", JSONFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, sink.Publish("t_block_metrics", testRows))
+	assert.NoError(t, sink.PublishDelete("t_block_metrics", []db.Condition{{Column: "f_slot", Operator: "=", Value: 33}}))
+	sink.Close()
+
+	records := consume(t, brokers, "goteth.t_block_metrics", 3)
+
+	// rows are keyed by slot
+	assert.Equal(t, "32", string(records[0].Key))
+	assert.Equal(t, insertOp, header(records[0], opHeader))
+	var row struct {
+		Slot     uint64 `json:"f_slot"`
+		Graffiti string `json:"f_graffiti"`
+	}
+	assert.NoError(t, json.Unmarshal(records[0].Value, &row))
+	assert.Equal(t, uint64(32), row.Slot)
+	assert.Equal(t, "goteth", row.Graffiti)
+
+	// the tombstone follows the row it deletes
+	tombstone := records[2]
+	assert.Equal(t, "33", string(tombstone.Key))
+	assert.Nil(t, tombstone.Value)
+	assert.Equal(t, deleteOp, header(tombstone, opHeader))
+	assert.Equal(t, "t_block_metrics", header(tombstone, tableHeader))
+	assert.Equal(t, "f_slot = 33", header(tombstone, whereHeader))
+}
+
+// With the brokers down, publishing does not wait for them: messages beyond the buffer are dropped
+func TestKafkaSinkDropsWhenFull(t *testing.T) {
+	defer func(records int) { maxBufferedRecords = records }(maxBufferedRecords)
+	maxBufferedRecords = 2
+
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.AllowAutoTopicCreation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink, err := NewKafkaSink(context.Background(), cluster.ListenAddrs(), "goteth", JSONFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(sink.client.Close)
+	cluster.Close()
+
+	rows := make([]db.Row, 10)
+	for i := range rows {
+		rows[i] = db.Row{"f_slot": uint64(i)}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, sink.Publish("t_block_metrics", rows))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publishing blocked with the brokers down")
+	}
+	// the producer reports the dropped messages in the background
+	assert.Eventually(t, func() bool {
+		return sink.DroppedMessages() >= uint64(len(rows)-maxBufferedRecords)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// Rows of the same epoch share the key, none of them replaces the other on a delete topic
+func TestKafkaSinkSameKey(t *testing.T) {
+	brokers := newTestBroker(t)
+	sink, err := NewKafkaSink(context.Background(), brokers, "goteth", JSONFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := []db.Row{
+		{"f_epoch": uint64(2), "f_val_idx": uint64(7)},
+		{"f_epoch": uint64(2), "f_val_idx": uint64(8)},
+	}
+	assert.NoError(t, sink.Publish("t_validator_rewards_summary", rows))
+	assert.NoError(t, sink.PublishDelete("t_validator_rewards_summary", []db.Condition{{Column: "f_epoch", Operator: "=", Value: 2}}))
+	sink.Close()
+
+	records := consume(t, brokers, "goteth.t_validator_rewards_summary", 3)
+	var valIdxs []uint64
+	for _, record := range records[:2] {
+		assert.Equal(t, "2", string(record.Key))
+		var row struct {
+			ValIdx uint64 `json:"f_val_idx"`
+		}
+		assert.NoError(t, json.Unmarshal(record.Value, &row))
+		valIdxs = append(valIdxs, row.ValIdx)
+	}
+	assert.Equal(t, []uint64{7, 8}, valIdxs)
+
+	// a single tombstone stands for both rows
+	assert.Equal(t, "2", string(records[2].Key))
+	assert.Nil(t, records[2].Value)
+	assert.Equal(t, "f_epoch = 2", header(records[2], whereHeader))
+}
+
+func TestKafkaSinkProtobuf(t *testing.T) {
+	brokers := newTestBroker(t)
+	sink, err := NewKafkaSink(context.Background(), brokers, "goteth", ProtobufFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := []db.Row{{
+		"f_epoch":            uint64(2),
+		"f_val_idx":          uint64(7),
+		"f_status":           uint8(1),
+		"f_exit_epoch":       uint64(18446744073709551615),
+		"f_relays":           []string{"relay-a"},
+		"f_inclusion_delay":  int64(-1),
+		"f_reward_fraction":  float32(0.5),
+		"f_in_sync_committe": false,
+	}}
+	assert.NoError(t, sink.Publish("t_validator_rewards_summary", rows))
+	sink.Close()
+
+	records := consume(t, brokers, "goteth.t_validator_rewards_summary", 1)
+	assert.Equal(t, "2", string(records[0].Key)) // rewards are keyed by epoch
+
+	var msg structpb.Struct
+	assert.NoError(t, proto.Unmarshal(records[0].Value, &msg))
+	fields := msg.AsMap()
+	assert.Equal(t, float64(7), fields["f_val_idx"])
+	assert.Equal(t, float64(1), fields["f_status"])
+	assert.Equal(t, "18446744073709551615", fields["f_exit_epoch"]) // too big for a number
+	assert.Equal(t, []any{"relay-a"}, fields["f_relays"])
+	assert.Equal(t, 0.5, fields["f_reward_fraction"])
+}
+
+func TestKafkaSinkFormat(t *testing.T) {
+	_, err := NewKafkaSink(context.Background(), []string{"localhost:9092"}, "goteth", "avro")
+	assert.Error(t, err)
+}
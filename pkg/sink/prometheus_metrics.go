@@ -0,0 +1,50 @@
+package sink
+
+import (
+	"strings"
+
+	"github.com/migalabs/goteth/pkg/metrics"
+	"github.com/migalabs/goteth/pkg/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	modName = "sink"
+
+	DroppedMessages = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: strings.ToLower(utils.CliName),
+		Subsystem: modName,
+		Name:      "dropped_messages",
+		Help:      "Number of messages that could not be published to the sink",
+	})
+)
+
+func (k *KafkaSink) GetPrometheusMetrics() *metrics.MetricsModule {
+	metricsMod := metrics.NewMetricsModule(
+		modName,
+		"metrics about the event sink",
+	)
+	metricsMod.AddIndvMetric(k.droppedMessagesMetric())
+	return metricsMod
+}
+
+func (k *KafkaSink) droppedMessagesMetric() *metrics.IndvMetrics {
+	initFn := func() error {
+		prometheus.MustRegister(DroppedMessages)
+		return nil
+	}
+	updateFn := func() (interface{}, error) {
+		dropped := k.DroppedMessages()
+		DroppedMessages.Set(float64(dropped))
+		return dropped, nil
+	}
+	droppedMessages, err := metrics.NewIndvMetrics(
+		"dropped_messages",
+		initFn,
+		updateFn,
+	)
+	if err != nil {
+		return nil
+	}
+	return droppedMessages
+}
@@ -14,3 +14,63 @@ type Attestation struct {
 func (f Attestation) Type() ModelType {
 	return AttestationModel
 }
+
+// First inclusion of a single validator vote
+type ValidatorAttestation struct {
+	ValIdx         phase0.ValidatorIndex
+	AttSlot        phase0.Slot
+	CommitteeIndex phase0.CommitteeIndex
+	InclusionSlot  phase0.Slot
+	InclusionDelay int
+}
+
+func (f ValidatorAttestation) Type() ModelType {
+	return ValidatorAttestationModel
+}
+
+// Returns the first inclusion of every validator vote for the given epoch
+// Blocks must be sorted by slot and should cover the epoch and the following one,
+// which is the maximum window in which an attestation can be included
+// Attestations whose committee cannot be resolved are skipped and reported in the error count
+func GetValidatorAttestations(
+	epoch phase0.Epoch,
+	duties EpochDuties,
+	blocks []*AgnosticBlock) (map[phase0.ValidatorIndex]ValidatorAttestation, int) {
+
+	result := make(map[phase0.ValidatorIndex]ValidatorAttestation)
+	unresolved := 0
+
+	for _, block := range blocks {
+		if block == nil || !block.Proposed {
+			continue
+		}
+		for _, attestation := range block.Attestations {
+			attSlot := attestation.Data.Slot
+			if phase0.Epoch(attSlot/SlotsPerEpoch) != epoch {
+				continue
+			}
+
+			attestingIndices, err := duties.GetAttestingIndices(attestation)
+			if err != nil {
+				log.Tracef("could not resolve attestation included at slot %d: %s", block.Slot, err)
+				unresolved += 1
+				continue
+			}
+
+			for _, valIdx := range attestingIndices {
+				if _, ok := result[valIdx]; ok {
+					continue // only keep the first inclusion
+				}
+				result[valIdx] = ValidatorAttestation{
+					ValIdx:         valIdx,
+					AttSlot:        attSlot,
+					CommitteeIndex: attestation.Data.Index,
+					InclusionSlot:  block.Slot,
+					InclusionDelay: int(block.Slot - attSlot),
+				}
+			}
+		}
+	}
+
+	return result, unresolved
+}
@@ -0,0 +1,154 @@
+package spec
+
+import (
+	"testing"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/assert"
+)
+
+// Duties with two committees per slot for the given epoch, validators are numbered by slot and position
+func syntheticDuties(epoch phase0.Epoch, committeeSize int) EpochDuties {
+	committees := make([]*api.BeaconCommittee, 0)
+	for slot := phase0.Slot(epoch) * SlotsPerEpoch; slot < phase0.Slot(epoch+1)*SlotsPerEpoch; slot++ {
+		for index := phase0.CommitteeIndex(0); index < 2; index++ {
+			validators := make([]phase0.ValidatorIndex, committeeSize)
+			for i := range validators {
+				validators[i] = phase0.ValidatorIndex((int(slot%SlotsPerEpoch)*2+int(index))*committeeSize + i)
+			}
+			committees = append(committees, &api.BeaconCommittee{Slot: slot, Index: index, Validators: validators})
+		}
+	}
+
+	duties := EpochDuties{}
+	duties.AddBeaconCommittees(committees)
+	return duties
+}
+
+func syntheticAttestation(slot phase0.Slot, index phase0.CommitteeIndex, committeeSize int, bits ...uint64) *phase0.Attestation {
+	aggregationBits := bitfield.NewBitlist(uint64(committeeSize))
+	for _, bit := range bits {
+		aggregationBits.SetBitAt(bit, true)
+	}
+	return &phase0.Attestation{
+		AggregationBits: aggregationBits,
+		Data:            &phase0.AttestationData{Slot: slot, Index: index},
+	}
+}
+
+func TestGetAttestingIndices(t *testing.T) {
+	duties := syntheticDuties(1, 4)
+	slot := phase0.Slot(SlotsPerEpoch) + 3
+
+	tests := []struct {
+		name        string
+		attestation *phase0.Attestation
+		expected    []phase0.ValidatorIndex
+		err         bool
+	}{
+		{
+			name:        "first committee",
+			attestation: syntheticAttestation(slot, 0, 4, 0, 2),
+			expected:    []phase0.ValidatorIndex{24, 26},
+		},
+		{
+			name:        "second committee",
+			attestation: syntheticAttestation(slot, 1, 4, 3),
+			expected:    []phase0.ValidatorIndex{31},
+		},
+		{
+			name:        "no votes",
+			attestation: syntheticAttestation(slot, 1, 4),
+			expected:    []phase0.ValidatorIndex{},
+		},
+		{
+			name:        "bit out of committee range",
+			attestation: syntheticAttestation(slot, 0, 5, 4),
+			err:         true,
+		},
+		{
+			name:        "unknown committee",
+			attestation: syntheticAttestation(slot, 2, 4, 0),
+			err:         true,
+		},
+		{
+			name:        "slot of another epoch",
+			attestation: syntheticAttestation(slot+SlotsPerEpoch, 0, 4, 0),
+			err:         true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			indices, err := duties.GetAttestingIndices(test.attestation)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, indices)
+		})
+	}
+}
+
+func TestGetValidatorAttestations(t *testing.T) {
+	epoch := phase0.Epoch(1)
+	duties := syntheticDuties(epoch, 4)
+	firstSlot := phase0.Slot(epoch) * SlotsPerEpoch
+
+	blocks := []*AgnosticBlock{
+		{
+			Slot:     firstSlot + 1,
+			Proposed: true,
+			Attestations: []*phase0.Attestation{
+				syntheticAttestation(firstSlot, 0, 4, 0, 1),
+				syntheticAttestation(firstSlot-1, 0, 4, 0), // previous epoch, skipped
+			},
+		},
+		{
+			Slot: firstSlot + 2, // missed, its attestations are not counted
+			Attestations: []*phase0.Attestation{
+				syntheticAttestation(firstSlot, 0, 4, 2),
+			},
+		},
+		{
+			Slot:     firstSlot + 3,
+			Proposed: true,
+			Attestations: []*phase0.Attestation{
+				syntheticAttestation(firstSlot, 0, 4, 1, 2), // validator 1 was already included
+				syntheticAttestation(firstSlot, 0, 5, 4),    // out of committee range, unresolved
+			},
+		},
+		{
+			Slot:     firstSlot + SlotsPerEpoch + 1,
+			Proposed: true,
+			Attestations: []*phase0.Attestation{
+				syntheticAttestation(firstSlot+SlotsPerEpoch-1, 1, 4, 3),
+			},
+		},
+	}
+
+	inclusions, unresolved := GetValidatorAttestations(epoch, duties, blocks)
+
+	assert.Equal(t, 1, unresolved)
+	assert.Len(t, inclusions, 4)
+
+	assert.Equal(t, ValidatorAttestation{
+		ValIdx:         0,
+		AttSlot:        firstSlot,
+		InclusionSlot:  firstSlot + 1,
+		InclusionDelay: 1,
+	}, inclusions[0])
+
+	// first inclusion wins
+	assert.Equal(t, firstSlot+1, inclusions[1].InclusionSlot)
+	assert.Equal(t, 1, inclusions[1].InclusionDelay)
+	assert.Equal(t, firstSlot+3, inclusions[2].InclusionSlot)
+	assert.Equal(t, 3, inclusions[2].InclusionDelay)
+
+	last := phase0.ValidatorIndex((int(SlotsPerEpoch)-1)*2*4 + 4 + 3)
+	assert.Equal(t, phase0.CommitteeIndex(1), inclusions[last].CommitteeIndex)
+	assert.Equal(t, 2, inclusions[last].InclusionDelay)
+}
@@ -16,28 +16,29 @@ import (
 
 // This Wrapper is meant to include all common objects across Ethereum Hard Fork Specs
 type AgnosticBlock struct {
-	Slot              phase0.Slot
-	StateRoot         phase0.Root
-	Root              phase0.Root
-	ParentRoot        phase0.Root
-	ProposerIndex     phase0.ValidatorIndex
-	Graffiti          [32]byte
-	Proposed          bool
-	Attestations      []*phase0.Attestation
-	VotesIncluded     uint64
-	NewVotesIncluded  uint64
-	Deposits          []*phase0.Deposit
-	ProposerSlashings []*phase0.ProposerSlashing
-	AttesterSlashings []*phase0.AttesterSlashing
-	VoluntaryExits    []*phase0.SignedVoluntaryExit
-	SyncAggregate     *altair.SyncAggregate
-	ExecutionPayload  AgnosticExecutionPayload
-	Reward            BlockRewards
-	SSZsize           uint32
-	SnappySize        uint32
-	CompressionTime   time.Duration
-	DecompressionTime time.Duration
-	ManualReward      phase0.Gwei
+	Slot                  phase0.Slot
+	StateRoot             phase0.Root
+	Root                  phase0.Root
+	ParentRoot            phase0.Root
+	ProposerIndex         phase0.ValidatorIndex
+	Graffiti              [32]byte
+	Proposed              bool
+	Attestations          []*phase0.Attestation
+	VotesIncluded         uint64
+	NewVotesIncluded      uint64
+	Deposits              []*phase0.Deposit
+	ProposerSlashings     []*phase0.ProposerSlashing
+	AttesterSlashings     []*phase0.AttesterSlashing
+	VoluntaryExits        []*phase0.SignedVoluntaryExit
+	BLSToExecutionChanges []*capella.SignedBLSToExecutionChange
+	SyncAggregate         *altair.SyncAggregate
+	ExecutionPayload      AgnosticExecutionPayload
+	Reward                BlockRewards
+	SSZsize               uint32
+	SnappySize            uint32
+	CompressionTime       time.Duration
+	DecompressionTime     time.Duration
+	ManualReward          phase0.Gwei
 }
 
 // This Wrapper is meant to include all common objects across Ethereum Hard Fork Specs
@@ -235,18 +236,19 @@ func NewCapellaBlock(block spec.VersionedSignedBeaconBlock) AgnosticBlock {
 		log.Fatalf("could not read root from block %d", block.Capella.Message.Slot)
 	}
 	return AgnosticBlock{
-		Slot:              block.Capella.Message.Slot,
-		Root:              root,
-		ParentRoot:        block.Capella.Message.ParentRoot,
-		ProposerIndex:     block.Capella.Message.ProposerIndex,
-		Graffiti:          block.Capella.Message.Body.Graffiti,
-		Proposed:          true,
-		Attestations:      block.Capella.Message.Body.Attestations,
-		Deposits:          block.Capella.Message.Body.Deposits,
-		ProposerSlashings: block.Capella.Message.Body.ProposerSlashings,
-		AttesterSlashings: block.Capella.Message.Body.AttesterSlashings,
-		VoluntaryExits:    block.Capella.Message.Body.VoluntaryExits,
-		SyncAggregate:     block.Capella.Message.Body.SyncAggregate,
+		Slot:                  block.Capella.Message.Slot,
+		Root:                  root,
+		ParentRoot:            block.Capella.Message.ParentRoot,
+		ProposerIndex:         block.Capella.Message.ProposerIndex,
+		Graffiti:              block.Capella.Message.Body.Graffiti,
+		Proposed:              true,
+		Attestations:          block.Capella.Message.Body.Attestations,
+		Deposits:              block.Capella.Message.Body.Deposits,
+		ProposerSlashings:     block.Capella.Message.Body.ProposerSlashings,
+		AttesterSlashings:     block.Capella.Message.Body.AttesterSlashings,
+		VoluntaryExits:        block.Capella.Message.Body.VoluntaryExits,
+		BLSToExecutionChanges: block.Capella.Message.Body.BLSToExecutionChanges,
+		SyncAggregate:         block.Capella.Message.Body.SyncAggregate,
 		ExecutionPayload: AgnosticExecutionPayload{
 			FeeRecipient:  block.Capella.Message.Body.ExecutionPayload.FeeRecipient,
 			GasLimit:      block.Capella.Message.Body.ExecutionPayload.GasLimit,
@@ -277,18 +279,19 @@ func NewDenebBlock(block spec.VersionedSignedBeaconBlock) AgnosticBlock {
 		log.Fatalf("could not read root from block %d", block.Deneb.Message.Slot)
 	}
 	return AgnosticBlock{
-		Slot:              block.Deneb.Message.Slot,
-		Root:              root,
-		ParentRoot:        block.Deneb.Message.ParentRoot,
-		ProposerIndex:     block.Deneb.Message.ProposerIndex,
-		Graffiti:          block.Deneb.Message.Body.Graffiti,
-		Proposed:          true,
-		Attestations:      block.Deneb.Message.Body.Attestations,
-		Deposits:          block.Deneb.Message.Body.Deposits,
-		ProposerSlashings: block.Deneb.Message.Body.ProposerSlashings,
-		AttesterSlashings: block.Deneb.Message.Body.AttesterSlashings,
-		VoluntaryExits:    block.Deneb.Message.Body.VoluntaryExits,
-		SyncAggregate:     block.Deneb.Message.Body.SyncAggregate,
+		Slot:                  block.Deneb.Message.Slot,
+		Root:                  root,
+		ParentRoot:            block.Deneb.Message.ParentRoot,
+		ProposerIndex:         block.Deneb.Message.ProposerIndex,
+		Graffiti:              block.Deneb.Message.Body.Graffiti,
+		Proposed:              true,
+		Attestations:          block.Deneb.Message.Body.Attestations,
+		Deposits:              block.Deneb.Message.Body.Deposits,
+		ProposerSlashings:     block.Deneb.Message.Body.ProposerSlashings,
+		AttesterSlashings:     block.Deneb.Message.Body.AttesterSlashings,
+		VoluntaryExits:        block.Deneb.Message.Body.VoluntaryExits,
+		BLSToExecutionChanges: block.Deneb.Message.Body.BLSToExecutionChanges,
+		SyncAggregate:         block.Deneb.Message.Body.SyncAggregate,
 		ExecutionPayload: AgnosticExecutionPayload{
 			FeeRecipient:  block.Deneb.Message.Body.ExecutionPayload.FeeRecipient,
 			GasLimit:      block.Deneb.Message.Body.ExecutionPayload.GasLimit,
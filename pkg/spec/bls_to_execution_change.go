@@ -0,0 +1,19 @@
+package spec
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Change of the withdrawal credentials of a validator from a BLS key to an execution address (from Capella)
+type BLSToExecutionChange struct {
+	Slot               phase0.Slot
+	Epoch              phase0.Epoch
+	ValidatorIndex     phase0.ValidatorIndex
+	FromBLSPublicKey   phase0.BLSPubKey
+	ToExecutionAddress bellatrix.ExecutionAddress
+}
+
+func (f BLSToExecutionChange) Type() ModelType {
+	return BLSToExecutionChangeModel
+}
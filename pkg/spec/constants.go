@@ -1,5 +1,7 @@
 package spec
 
+import "github.com/attestantio/go-eth2-client/spec/phase0"
+
 const (
 	MainnetGenesis = 1606824023
 	SepoliaGenesis = 1655733600
@@ -35,6 +37,7 @@ const (
 	SlotsPerHistoricalRoot      = 8192
 	WhistleBlowerRewardQuotient = 512
 	MinInclusionDelay           = 1
+	FarFutureEpoch              = phase0.Epoch(18446744073709551615) // 2**64 - 1
 
 	AttSourceFlagIndex = 0
 	AttTargetFlagIndex = 1
@@ -62,6 +65,23 @@ const (
 	ProposerWeight    = 8
 	WeightDenominator = 64
 	SyncCommitteeSize = 512
+
+	EpochsPerSyncCommitteePeriod = 256
+)
+
+const (
+	// penalty constants
+	MinEpochsToInactivityPenalty = 4
+	InactivityScoreBias          = 4
+	EpochsPerSlashingsVector     = 8192
+
+	InactivityPenaltyQuotientAltair      = 3 * (1 << 24)
+	MinSlashingPenaltyQuotientAltair     = 64
+	ProportionalSlashingMultiplierAltair = 2
+
+	InactivityPenaltyQuotientBellatrix      = 1 << 24
+	MinSlashingPenaltyQuotientBellatrix     = 32
+	ProportionalSlashingMultiplierBellatrix = 3
 )
 
 var (
@@ -90,6 +110,17 @@ const (
 	FinalizedCheckpointModel
 	HeadEventModel
 	AttestationModel
+	ValidatorAttestationModel
+	SyncCommitteeParticipationModel
+	SyncCommitteeMemberModel
+	RewardsAuditModel
+	EpochFinalityModel
+	ValidatorEventModel
+	ValidatorStatusHistoryModel
+	BLSToExecutionChangeModel
+	DepositModel
+	VoluntaryExitModel
+	SlashingModel
 )
 
 type ValidatorStatus int8
@@ -101,3 +132,25 @@ const (
 	SLASHED_STATUS
 	NUMBER_OF_STATUS // Add new status before this
 )
+
+// First byte of the withdrawal credentials
+const (
+	BLSWithdrawalPrefix              = 0x00
+	ExecutionAddressWithdrawalPrefix = 0x01
+	CompoundingWithdrawalPrefix      = 0x02
+)
+
+type ValidatorEventType int8
+
+const (
+	DEPOSITED_EVENT ValidatorEventType = iota
+	ELIGIBLE_EVENT
+	ACTIVATED_EVENT
+	EXIT_INITIATED_EVENT
+	EXITED_EVENT
+	SLASHED_EVENT
+	WITHDRAWABLE_EVENT
+	FULLY_WITHDRAWN_EVENT
+	CREDENTIALS_CHANGE_EVENT
+	NUMBER_OF_EVENTS // Add new events before this
+)
@@ -0,0 +1,88 @@
+package spec
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type DepositType uint8
+
+const (
+	NEW_VALIDATOR_DEPOSIT DepositType = iota
+	TOP_UP_DEPOSIT
+	INVALID_DEPOSIT // the deposit did not create a validator (e.g. invalid signature)
+)
+
+// Deposit included in a beacon block
+type Deposit struct {
+	Slot                  phase0.Slot
+	Epoch                 phase0.Epoch
+	Index                 uint64 // index of the deposit in the deposit contract
+	PublicKey             phase0.BLSPubKey
+	WithdrawalCredentials []byte
+	Amount                phase0.Gwei
+	ValidatorIndex        phase0.ValidatorIndex
+	DepositType           DepositType
+	ELBlockNumber         uint64         // only when the execution layer is available
+	ELTxHash              common.Hash    // only when the execution layer is available
+	ELSender              common.Address // only when the execution layer is available
+}
+
+func (f Deposit) Type() ModelType {
+	return DepositModel
+}
+
+// DepositEvent log emitted by the deposit contract in the execution layer
+type DepositEvent struct {
+	Index       uint64
+	BlockNumber uint64
+	TxHash      common.Hash
+	Sender      common.Address
+}
+
+// Returns the deposits included in the nextState blocks, resolving the validator index of each of them.
+// Deposits for a public key not present in the prevState registry create a new validator
+func GetDeposits(prevState *AgnosticState, nextState *AgnosticState) []Deposit {
+
+	deposits := make([]Deposit, 0)
+	for _, block := range nextState.Blocks {
+		for _, deposit := range block.Deposits {
+			deposits = append(deposits, Deposit{
+				Slot:                  block.Slot,
+				Epoch:                 phase0.Epoch(block.Slot / SlotsPerEpoch),
+				PublicKey:             deposit.Data.PublicKey,
+				WithdrawalCredentials: deposit.Data.WithdrawalCredentials,
+				Amount:                deposit.Data.Amount,
+			})
+		}
+	}
+	if len(deposits) == 0 {
+		return deposits
+	}
+
+	// deposits are processed in order, the last one of the epoch is right before the state deposit index
+	firstIndex := nextState.Eth1DepositIndex - uint64(len(deposits))
+
+	prevIndices := prevState.PubkeyIndices()
+	nextIndices := nextState.PubkeyIndices()
+	created := make(map[phase0.BLSPubKey]bool) // validators created by a previous deposit of the epoch
+	for i := range deposits {
+		deposits[i].Index = firstIndex + uint64(i)
+
+		valIdx, ok := nextIndices[deposits[i].PublicKey]
+		if !ok {
+			deposits[i].DepositType = INVALID_DEPOSIT
+			continue
+		}
+		deposits[i].ValidatorIndex = valIdx
+
+		if _, existed := prevIndices[deposits[i].PublicKey]; existed || created[deposits[i].PublicKey] {
+			deposits[i].DepositType = TOP_UP_DEPOSIT
+		} else {
+			deposits[i].DepositType = NEW_VALIDATOR_DEPOSIT
+			created[deposits[i].PublicKey] = true // next deposits for the same key are top ups
+		}
+	}
+
+	return deposits
+}
@@ -0,0 +1,124 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+)
+
+func depositOf(pubkey phase0.BLSPubKey, amount phase0.Gwei) *phase0.Deposit {
+	return &phase0.Deposit{
+		Data: &phase0.DepositData{
+			PublicKey:             pubkey,
+			WithdrawalCredentials: []byte{0x01},
+			Amount:                amount,
+		},
+	}
+}
+
+// States with numValidators validators before and after the deposits of a block at slot 40
+func depositStates(prevValidators int, nextValidators int, deposits ...*phase0.Deposit) (*AgnosticState, *AgnosticState) {
+	prevState := &AgnosticState{Validators: make([]*phase0.Validator, prevValidators)}
+	nextState := &AgnosticState{
+		Validators:       make([]*phase0.Validator, nextValidators),
+		Blocks:           []*AgnosticBlock{{Slot: 40}, {Slot: 41, Deposits: deposits}},
+		Eth1DepositIndex: 100,
+	}
+	for i := range nextState.Validators {
+		nextState.Validators[i] = &phase0.Validator{PublicKey: syntheticPubkey(i)}
+		if i < prevValidators {
+			prevState.Validators[i] = nextState.Validators[i]
+		}
+	}
+	return prevState, nextState
+}
+
+func TestGetDeposits(t *testing.T) {
+	tests := []struct {
+		name           string
+		prevValidators int
+		nextValidators int
+		deposits       []*phase0.Deposit
+		expected       []Deposit
+	}{
+		{
+			name:           "no deposits",
+			prevValidators: 2,
+			nextValidators: 2,
+			expected:       []Deposit{},
+		},
+		{
+			name:           "new validator",
+			prevValidators: 2,
+			nextValidators: 3,
+			deposits:       []*phase0.Deposit{depositOf(syntheticPubkey(2), 32)},
+			expected: []Deposit{
+				{Index: 99, PublicKey: syntheticPubkey(2), Amount: 32, ValidatorIndex: 2, DepositType: NEW_VALIDATOR_DEPOSIT},
+			},
+		},
+		{
+			name:           "top up",
+			prevValidators: 2,
+			nextValidators: 2,
+			deposits:       []*phase0.Deposit{depositOf(syntheticPubkey(1), 1)},
+			expected: []Deposit{
+				{Index: 99, PublicKey: syntheticPubkey(1), Amount: 1, ValidatorIndex: 1, DepositType: TOP_UP_DEPOSIT},
+			},
+		},
+		{
+			name:           "invalid deposit does not create a validator",
+			prevValidators: 2,
+			nextValidators: 2,
+			deposits:       []*phase0.Deposit{depositOf(syntheticPubkey(5), 32)},
+			expected: []Deposit{
+				{Index: 99, PublicKey: syntheticPubkey(5), Amount: 32, DepositType: INVALID_DEPOSIT},
+			},
+		},
+		{
+			name:           "new validator topped up in the same epoch",
+			prevValidators: 2,
+			nextValidators: 3,
+			deposits: []*phase0.Deposit{
+				depositOf(syntheticPubkey(0), 2),
+				depositOf(syntheticPubkey(2), 32),
+				depositOf(syntheticPubkey(2), 3),
+			},
+			expected: []Deposit{
+				{Index: 97, PublicKey: syntheticPubkey(0), Amount: 2, ValidatorIndex: 0, DepositType: TOP_UP_DEPOSIT},
+				{Index: 98, PublicKey: syntheticPubkey(2), Amount: 32, ValidatorIndex: 2, DepositType: NEW_VALIDATOR_DEPOSIT},
+				{Index: 99, PublicKey: syntheticPubkey(2), Amount: 3, ValidatorIndex: 2, DepositType: TOP_UP_DEPOSIT},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prevState, nextState := depositStates(test.prevValidators, test.nextValidators, test.deposits...)
+
+			deposits := GetDeposits(prevState, nextState)
+
+			for i := range test.expected {
+				test.expected[i].Slot = 41
+				test.expected[i].Epoch = phase0.Epoch(41 / SlotsPerEpoch)
+				test.expected[i].WithdrawalCredentials = []byte{0x01}
+			}
+			assert.Equal(t, test.expected, deposits)
+		})
+	}
+}
+
+// The pubkey index is built once per state and shared, resolving deposits must not modify it
+func TestGetDepositsKeepsPubkeyIndex(t *testing.T) {
+	prevState, nextState := depositStates(2, 3, depositOf(syntheticPubkey(2), 32), depositOf(syntheticPubkey(2), 1))
+	prevState.pubkeyIndices = buildPubkeyIndices(prevState.Validators)
+	nextState.pubkeyIndices = buildPubkeyIndices(nextState.Validators)
+
+	deposits := GetDeposits(prevState, nextState)
+
+	assert.Equal(t, NEW_VALIDATOR_DEPOSIT, deposits[0].DepositType)
+	assert.Equal(t, TOP_UP_DEPOSIT, deposits[1].DepositType)
+	assert.Len(t, prevState.PubkeyIndices(), 2)
+	_, ok := prevState.PubkeyIndices()[syntheticPubkey(2)]
+	assert.False(t, ok)
+}
@@ -1,6 +1,7 @@
 package spec
 
 import (
+	"fmt"
 	"math"
 
 	api "github.com/attestantio/go-eth2-client/api/v1"
@@ -8,12 +9,40 @@ import (
 )
 
 type EpochDuties struct {
-	ProposerDuties   []*api.ProposerDuty                   // 32 Proposer Duties per Epoch
-	BeaconCommittees []*api.BeaconCommittee                // Beacon Committees organized by slot for the whole epoch
-	ValidatorAttSlot map[phase0.ValidatorIndex]phase0.Slot // for each validator we have which slot it had to attest to
+	ProposerDuties   []*api.ProposerDuty                                               // 32 Proposer Duties per Epoch
+	BeaconCommittees []*api.BeaconCommittee                                            // Beacon Committees organized by slot for the whole epoch
+	ValidatorAttSlot map[phase0.ValidatorIndex]phase0.Slot                             // for each validator we have which slot it had to attest to
+	CommitteesBySlot map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex // beacon committees indexed by slot and committee index
+}
+
+// Stores the beacon committees of the epoch and builds the lookup structures
+func (p *EpochDuties) AddBeaconCommittees(committees []*api.BeaconCommittee) {
+	p.BeaconCommittees = committees
+	p.ValidatorAttSlot = make(map[phase0.ValidatorIndex]phase0.Slot)
+	p.CommitteesBySlot = make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+
+	for _, committee := range committees {
+		if _, ok := p.CommitteesBySlot[committee.Slot]; !ok {
+			p.CommitteesBySlot[committee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+		}
+		p.CommitteesBySlot[committee.Slot][committee.Index] = committee.Validators
+
+		for _, valIdx := range committee.Validators {
+			p.ValidatorAttSlot[valIdx] = committee.Slot
+		}
+	}
+}
+
+// Committees are only downloaded when a metric resolves attestations to validators
+func (p EpochDuties) HasCommittees() bool {
+	return p.CommitteesBySlot != nil
 }
 
 func (p EpochDuties) GetValList(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) []phase0.ValidatorIndex {
+	if p.CommitteesBySlot != nil {
+		return p.CommitteesBySlot[slot][committeeIndex]
+	}
+
 	for _, committee := range p.BeaconCommittees {
 		if (committee.Slot == slot) && (committee.Index == committeeIndex) {
 			return committee.Validators
@@ -23,6 +52,25 @@ func (p EpochDuties) GetValList(slot phase0.Slot, committeeIndex phase0.Committe
 	return nil
 }
 
+// Returns the validator indices whose vote is included in the given attestation
+// The aggregation bits are resolved against the beacon committee of the attested slot
+func (p EpochDuties) GetAttestingIndices(attestation *phase0.Attestation) ([]phase0.ValidatorIndex, error) {
+	committee := p.GetValList(attestation.Data.Slot, attestation.Data.Index)
+	if committee == nil {
+		return nil, fmt.Errorf("no beacon committee found: slot %d, committee %d", attestation.Data.Slot, attestation.Data.Index)
+	}
+
+	attestingIndices := make([]phase0.ValidatorIndex, 0)
+	for _, idx := range attestation.AggregationBits.BitIndices() {
+		if idx >= len(committee) {
+			return nil, fmt.Errorf("aggregation bit %d out of committee range: slot %d, committee %d", idx, attestation.Data.Slot, attestation.Data.Index)
+		}
+		attestingIndices = append(attestingIndices, committee[idx])
+	}
+
+	return attestingIndices, nil
+}
+
 func GetEffectiveBalance(balance float64) float64 {
 	return math.Min(MaxEffectiveInc*EffectiveBalanceInc, balance)
 }
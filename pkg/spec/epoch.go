@@ -25,6 +25,8 @@ type Epoch struct {
 	NumActiveVals             int
 	NumExitedVals             int
 	NumInActivationVals       int
+	EpochsSinceFinality       phase0.Epoch
+	InactivityLeak            bool
 }
 
 func (f Epoch) Type() ModelType {
@@ -0,0 +1,42 @@
+package spec
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Justification and finalization status of the chain at the end of an epoch
+type EpochFinality struct {
+	Epoch                       phase0.Epoch
+	Slot                        phase0.Slot
+	JustificationBits           uint8 // justification of the 4 epochs before this one, bit 0 is the most recent
+	PreviousJustifiedCheckpoint phase0.Checkpoint
+	CurrentJustifiedCheckpoint  phase0.Checkpoint
+	FinalizedCheckpoint         phase0.Checkpoint
+}
+
+func (f EpochFinality) Type() ModelType {
+	return EpochFinalityModel
+}
+
+func (f EpochFinality) EpochsToFinality() phase0.Epoch {
+	if f.Epoch < f.FinalizedCheckpoint.Epoch {
+		return 0
+	}
+	return f.Epoch - f.FinalizedCheckpoint.Epoch
+}
+
+func (p AgnosticState) ExportToEpochFinality() EpochFinality {
+	justificationBits := uint8(0)
+	if len(p.JustificationBits) > 0 {
+		justificationBits = p.JustificationBits.Bytes()[0]
+	}
+
+	return EpochFinality{
+		Epoch:                       p.Epoch,
+		Slot:                        p.Slot,
+		JustificationBits:           justificationBits,
+		PreviousJustifiedCheckpoint: p.PreviousJustifiedCheckpoint,
+		CurrentJustifiedCheckpoint:  p.CurrentJustifiedCheckpoint,
+		FinalizedCheckpoint:         p.FinalizedCheckpoint,
+	}
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/attestantio/go-eth2-client/http"
 	"github.com/attestantio/go-eth2-client/spec"
@@ -75,6 +76,18 @@ func StateMetricsByForkVersion(
 	}
 }
 
+// Epochs between the previous epoch and the finalized checkpoint at the epoch transition into NextState
+func (s StateMetricsBase) FinalityDelay() phase0.Epoch {
+	if s.NextState.Epoch < 2 {
+		return 0
+	}
+	prevEpoch := s.NextState.Epoch - 2
+	if prevEpoch < s.NextState.FinalizedCheckpoint.Epoch {
+		return 0
+	}
+	return prevEpoch - s.NextState.FinalizedCheckpoint.Epoch
+}
+
 func (s StateMetricsBase) ExportToEpoch() local_spec.Epoch {
 
 	return local_spec.Epoch{
@@ -97,5 +110,91 @@ func (s StateMetricsBase) ExportToEpoch() local_spec.Epoch {
 		NumActiveVals:             int(s.CurrentState.NumActiveVals),
 		NumExitedVals:             int(s.CurrentState.NumExitedVals),
 		NumInActivationVals:       int(s.CurrentState.NumQueuedVals),
+		EpochsSinceFinality:       s.CurrentState.Epoch - s.CurrentState.FinalizedCheckpoint.Epoch,
+		InactivityLeak:            s.FinalityDelay() > local_spec.MinEpochsToInactivityPenalty, // same leak as the rewards of the transition
+	}
+}
+
+// Resolves the attestations included in CurrentState and NextState blocks into
+// the first inclusion of every validator vote for the CurrentState epoch
+func (s StateMetricsBase) ExportToValidatorAttestations() []local_spec.ValidatorAttestation {
+
+	blocks := make([]*local_spec.AgnosticBlock, 0, len(s.CurrentState.Blocks)+len(s.NextState.Blocks))
+	blocks = append(blocks, s.CurrentState.Blocks...)
+	blocks = append(blocks, s.NextState.Blocks...)
+
+	inclusions, unresolved := local_spec.GetValidatorAttestations(s.CurrentState.Epoch, s.CurrentState.EpochStructs, blocks)
+	if unresolved > 0 {
+		log.Warnf("epoch %d: %d attestations could not be resolved against the beacon committees", s.CurrentState.Epoch, unresolved)
+	}
+
+	result := make([]local_spec.ValidatorAttestation, 0, len(inclusions))
+	for _, item := range inclusions {
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ValIdx < result[j].ValIdx
+	})
+
+	return result
+}
+
+func (s StateMetricsBase) ExportToValidatorEvents() []local_spec.ValidatorEvent {
+
+	return local_spec.GetValidatorEvents(s.CurrentState, s.NextState)
+}
+
+func (s StateMetricsBase) ExportToValidatorStatusHistory() []local_spec.ValidatorStatusHistory {
+
+	return local_spec.GetValidatorStatusChanges(s.CurrentState, s.NextState)
+}
+
+func (s StateMetricsBase) ExportToDeposits() []local_spec.Deposit {
+
+	return local_spec.GetDeposits(s.CurrentState, s.NextState)
+}
+
+// Slashings included in NextState blocks, with the reward credited to the whistleblower.
+// Validators that were already slashed are not slashed again, so they are not returned
+func (s StateMetricsBase) ExportToSlashings() []local_spec.Slashing {
+
+	result := make([]local_spec.Slashing, 0)
+	slashed := make(map[phase0.ValidatorIndex]bool)
+	for _, block := range s.NextState.Blocks {
+		for _, slashing := range getBlockSlashings(block) {
+			valIdx := slashing.SlashedValidator
+			if slashed[valIdx] {
+				continue
+			}
+			if int(valIdx) < len(s.CurrentState.Validators) && s.CurrentState.Validators[valIdx].Slashed {
+				continue // already slashed before, not slashable again
+			}
+			slashed[valIdx] = true
+
+			// slash_validator uses the effective balance at slashing time, NextState may already hold a lowered one
+			if slashing.SlashedValidator < phase0.ValidatorIndex(len(s.CurrentState.Validators)) {
+				slashedEffBalance := s.CurrentState.Validators[slashing.SlashedValidator].EffectiveBalance
+				slashing.WhistleblowerReward = slashedEffBalance / local_spec.WhistleBlowerRewardQuotient
+			}
+			result = append(result, slashing)
+		}
 	}
+	return result
+}
+
+func (s StateMetricsBase) ExportToSyncCommitteeParticipation() []local_spec.ValidatorSyncParticipation {
+
+	return local_spec.GetSyncCommitteeParticipation(
+		s.NextState.Epoch,
+		s.NextState.SyncCommittee,
+		s.NextState.PubkeyIndices(),
+		s.NextState.Blocks)
+}
+
+func (s StateMetricsBase) ExportToSyncCommitteeMembers() []local_spec.SyncCommitteeMember {
+
+	return local_spec.GetSyncCommitteeMembers(
+		local_spec.GetSyncCommitteePeriod(s.NextState.Epoch),
+		s.NextState.SyncCommittee,
+		s.NextState.PubkeyIndices())
 }
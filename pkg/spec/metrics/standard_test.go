@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/spec"
+	"github.com/migalabs/goteth/pkg/spec/spectest"
+	"github.com/stretchr/testify/assert"
+)
+
+func proposerSlashing(valIdx phase0.ValidatorIndex) *phase0.ProposerSlashing {
+	return &phase0.ProposerSlashing{
+		SignedHeader1: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{ProposerIndex: valIdx}},
+		SignedHeader2: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{ProposerIndex: valIdx}},
+	}
+}
+
+func attesterSlashing(indices1 []uint64, indices2 []uint64) *phase0.AttesterSlashing {
+	return &phase0.AttesterSlashing{
+		Attestation1: &phase0.IndexedAttestation{AttestingIndices: indices1},
+		Attestation2: &phase0.IndexedAttestation{AttestingIndices: indices2},
+	}
+}
+
+func TestExportToSlashings(t *testing.T) {
+	numValidators := 6
+	base := StateMetricsBase{
+		CurrentState: spectest.State(2, numValidators),
+		NextState:    spectest.State(3, numValidators),
+	}
+	for valIdx := 0; valIdx < numValidators; valIdx++ {
+		base.CurrentState.Validators[valIdx].EffectiveBalance = 32_000_000_000
+		base.NextState.Validators[valIdx].EffectiveBalance = 31_000_000_000 // lowered by a later transition
+	}
+	base.CurrentState.Validators[1].Slashed = true // slashed in a previous epoch
+
+	firstSlot := phase0.Slot(base.NextState.Epoch) * spec.SlotsPerEpoch
+	base.NextState.Blocks[1].Slot = firstSlot + 1
+	base.NextState.Blocks[1].ProposerIndex = 5
+	base.NextState.Blocks[1].ProposerSlashings = []*phase0.ProposerSlashing{proposerSlashing(0), proposerSlashing(1)}
+	base.NextState.Blocks[2].Slot = firstSlot + 2
+	base.NextState.Blocks[2].ProposerIndex = 4
+	base.NextState.Blocks[2].AttesterSlashings = []*phase0.AttesterSlashing{attesterSlashing([]uint64{0, 1, 2, 3}, []uint64{0, 1, 2})}
+
+	slashings := base.ExportToSlashings()
+
+	reward := phase0.Gwei(32_000_000_000 / spec.WhistleBlowerRewardQuotient)
+	assert.Equal(t, []spec.Slashing{
+		{
+			SlashedValidator:    0,
+			Slot:                firstSlot + 1,
+			Epoch:               base.NextState.Epoch,
+			SlashingType:        spec.PROPOSER_SLASHING,
+			Whistleblower:       5,
+			WhistleblowerReward: reward,
+		},
+		{
+			SlashedValidator:    2,
+			Slot:                firstSlot + 2,
+			Epoch:               base.NextState.Epoch,
+			SlashingType:        spec.ATTESTER_SLASHING,
+			Whistleblower:       4,
+			WhistleblowerReward: reward,
+		},
+	}, slashings)
+}
@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -11,6 +12,7 @@ import (
 type AltairMetrics struct {
 	Phase0Metrics
 	MaxSyncCommitteeRewards map[phase0.ValidatorIndex]phase0.Gwei // rewards from participating in the sync committee
+	RewardComponents        []spec.RewardComponents               // actual rewards and penalties, one per validator
 }
 
 func NewAltairMetrics(
@@ -38,6 +40,7 @@ func (p *AltairMetrics) InitBundle(nextState *spec.AgnosticState,
 	p.baseMetrics.InclusionDelays = make([]int, len(p.baseMetrics.NextState.Validators))
 	p.baseMetrics.MaxAttesterRewards = make(map[phase0.ValidatorIndex]phase0.Gwei)
 	p.MaxSyncCommitteeRewards = make(map[phase0.ValidatorIndex]phase0.Gwei)
+	p.RewardComponents = make([]spec.RewardComponents, len(p.baseMetrics.NextState.Validators))
 	p.baseMetrics.CurrentNumAttestingVals = make([]bool, len(currentState.Validators))
 }
 
@@ -45,10 +48,13 @@ func (p *AltairMetrics) PreProcessBundle() {
 
 	if !p.baseMetrics.PrevState.EmptyStateRoot() && !p.baseMetrics.CurrentState.EmptyStateRoot() {
 		// block rewards
-		// p.ProcessAttestations()
-		// p.ProcessInclusionDelays()
+		if p.baseMetrics.NextState.EpochStructs.HasCommittees() {
+			p.ProcessAttestations()
+			p.ProcessInclusionDelays()
+		}
 		p.ProcessSlashings()
 		p.ProcessSyncAggregates()
+		p.ProcessRewardComponents()
 
 		p.GetMaxFlagIndexDeltas()
 		p.GetMaxSyncComReward()
@@ -62,16 +68,10 @@ func (p AltairMetrics) GetMetricsBase() StateMetricsBase {
 func (p *AltairMetrics) ProcessSlashings() {
 
 	for _, block := range p.GetMetricsBase().NextState.Blocks {
-		slashedIdxs := make([]phase0.ValidatorIndex, 0)
+		slashedIdxs := getSlashedValidators(block)
 		whistleBlowerIdx := block.ProposerIndex // spec always contemplates whistleblower to be the block proposer
 		whistleBlowerReward := phase0.Gwei(0)
 		proposerReward := phase0.Gwei(0)
-		for _, attSlashing := range block.AttesterSlashings {
-			slashedIdxs = append(slashedIdxs, spec.SlashingIntersection(attSlashing.Attestation1.AttestingIndices, attSlashing.Attestation2.AttestingIndices)...)
-		}
-		for _, proposerSlashing := range block.ProposerSlashings {
-			slashedIdxs = append(slashedIdxs, proposerSlashing.SignedHeader1.Message.ProposerIndex)
-		}
 
 		for _, idx := range slashedIdxs {
 			slashedEffBalance := p.baseMetrics.NextState.Validators[idx].EffectiveBalance
@@ -101,126 +101,334 @@ func (p AltairMetrics) ProcessSyncAggregates() {
 }
 
 func (p *AltairMetrics) ProcessInclusionDelays() {
-	for _, block := range append(p.baseMetrics.PrevState.Blocks, p.baseMetrics.CurrentState.Blocks...) {
-		// we assume the blocks are in order asc
-		for _, attestation := range block.Attestations {
-			attSlot := attestation.Data.Slot
-			// Calculate inclusion delays only for attestations corresponding to slots from the previous epoch
-			attSlotNotInPrevEpoch := attSlot < phase0.Slot(p.baseMetrics.PrevState.Epoch)*spec.SlotsPerEpoch || attSlot >= phase0.Slot(p.baseMetrics.CurrentState.Epoch)*spec.SlotsPerEpoch
-			if attSlotNotInPrevEpoch {
-				continue
-			}
-			inclusionDelay := p.GetInclusionDelay(*attestation, *block)
-			committeIndex := attestation.Data.Index
-
-			attestingIndices := attestation.AggregationBits.BitIndices()
-
-			for _, idx := range attestingIndices {
-				valIdx, err := p.GetValidatorFromCommitteeIndex(attSlot, committeIndex, idx)
-				if err != nil {
-					log.Fatalf("error processing attestations at block %d: %s", block.Slot, err)
-				}
+	p.processInclusionDelays(p.maxInclusionDelay)
+}
 
-				if p.baseMetrics.InclusionDelays[valIdx] == 0 {
-					p.baseMetrics.InclusionDelays[valIdx] = inclusionDelay
-				}
-			}
-		}
+// Fills the inclusion delay of every validator for its attestation to the PrevState epoch
+// Validators whose vote was not included get the maximum inclusion delay + 1
+func (p *AltairMetrics) processInclusionDelays(maxInclusionDelay func(phase0.ValidatorIndex) int) {
+	// the attestations to the PrevState epoch can only be included in PrevState or CurrentState blocks
+	blocks := make([]*spec.AgnosticBlock, 0, len(p.baseMetrics.PrevState.Blocks)+len(p.baseMetrics.CurrentState.Blocks))
+	blocks = append(blocks, p.baseMetrics.PrevState.Blocks...)
+	blocks = append(blocks, p.baseMetrics.CurrentState.Blocks...)
+
+	inclusions, unresolved := spec.GetValidatorAttestations(
+		p.baseMetrics.PrevState.Epoch,
+		p.baseMetrics.PrevState.EpochStructs,
+		blocks)
+	if unresolved > 0 {
+		log.Warnf("epoch %d: %d attestations could not be resolved to validators", p.baseMetrics.PrevState.Epoch, unresolved)
 	}
 
-	for valIdx, inclusionDelay := range p.baseMetrics.InclusionDelays {
-		if inclusionDelay == 0 {
-			p.baseMetrics.InclusionDelays[valIdx] = p.maxInclusionDelay(phase0.ValidatorIndex(valIdx)) + 1
+	for valIdx := range p.baseMetrics.InclusionDelays {
+		if inclusion, ok := inclusions[phase0.ValidatorIndex(valIdx)]; ok {
+			p.baseMetrics.InclusionDelays[valIdx] = inclusion.InclusionDelay
+			continue
 		}
+		p.baseMetrics.InclusionDelays[valIdx] = maxInclusionDelay(phase0.ValidatorIndex(valIdx)) + 1
 	}
 }
 
 // https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/beacon-chain.md#modified-process_attestation
 func (p AltairMetrics) ProcessAttestations() {
+	p.processAttestations(p.getParticipationFlags)
+}
+
+func (p AltairMetrics) processAttestations(
+	getParticipationFlags func(phase0.Attestation, spec.AgnosticBlock) ([3]bool, error)) {
 
 	if p.baseMetrics.CurrentState.Blocks == nil { // only process attestations when CurrentState available
 		return
 	}
 
-	currentEpochParticipation := make([][]bool, len(p.baseMetrics.CurrentState.Validators))
-	nextEpochParticipation := make([][]bool, len(p.baseMetrics.NextState.Validators))
+	currentEpochParticipation := make([][3]bool, len(p.baseMetrics.CurrentState.Validators))
+	nextEpochParticipation := make([][3]bool, len(p.baseMetrics.NextState.Validators))
 
-	blockList := p.baseMetrics.CurrentState.Blocks
-	blockList = append(
-		blockList,
-		p.baseMetrics.NextState.Blocks...)
+	// we are only counting rewards at NextState
+	baseRewardPerInc := p.GetBaseRewardPerInc(p.baseMetrics.NextState.TotalActiveBalance)
+	denominator := phase0.Gwei((spec.WeightDenominator - spec.ProposerWeight) * spec.WeightDenominator / spec.ProposerWeight)
+	firstCurrentSlot := phase0.Slot(p.baseMetrics.CurrentState.Epoch) * spec.SlotsPerEpoch
+	firstNextSlot := phase0.Slot(p.baseMetrics.NextState.Epoch) * spec.SlotsPerEpoch
+
+	// CurrentState blocks are only used to know which flags were already achieved
+	blockList := make([]*spec.AgnosticBlock, 0, len(p.baseMetrics.CurrentState.Blocks)+len(p.baseMetrics.NextState.Blocks))
+	blockList = append(blockList, p.baseMetrics.CurrentState.Blocks...)
+	blockList = append(blockList, p.baseMetrics.NextState.Blocks...)
 
 	for _, block := range blockList {
+		// rewards of CurrentState blocks were already counted in the previous epoch
+		blockInNextState := block.Slot >= firstNextSlot
 
 		for _, attestation := range block.Attestations {
 
-			attReward := phase0.Gwei(0)
 			slot := attestation.Data.Slot
+			if slot < firstCurrentSlot {
+				continue
+			}
+
+			attInCurrentEpoch := slotInEpoch(slot, p.baseMetrics.CurrentState.Epoch)
 			epochParticipation := nextEpochParticipation
-			if slotInEpoch(slot, p.baseMetrics.CurrentState.Epoch) {
+			if attInCurrentEpoch {
 				epochParticipation = currentEpochParticipation
 			}
 
-			if slot < phase0.Slot(p.baseMetrics.CurrentState.Epoch)*spec.SlotsPerEpoch {
+			participationFlags, err := getParticipationFlags(*attestation, *block)
+			if err != nil {
+				log.Errorf("could not get participation flags of attestation included at slot %d: %s", block.Slot, err)
 				continue
 			}
 
-			participationFlags := p.getParticipationFlags(*attestation, *block)
-
-			committeIndex := attestation.Data.Index
-
-			attestingIndices := attestation.AggregationBits.BitIndices()
-
-			for _, idx := range attestingIndices {
-				block.VotesIncluded += 1
+			attestingIndices, err := p.baseMetrics.GetAttestingIndices(attestation)
+			if err != nil {
+				log.Errorf("could not process attestation included at slot %d: %s", block.Slot, err)
+				continue
+			}
 
-				valIdx, err := p.GetValidatorFromCommitteeIndex(slot, committeIndex, idx)
-				if err != nil {
-					log.Fatalf("error processing attestations at block %d: %s", block.Slot, err)
-				}
-				if epochParticipation[valIdx] == nil {
-					epochParticipation[valIdx] = make([]bool, len(spec.ParticipatingFlagsWeight))
+			attReward := phase0.Gwei(0)
+			for _, valIdx := range attestingIndices {
+				if int(valIdx) >= len(epochParticipation) || int(valIdx) >= len(p.baseMetrics.NextState.Validators) {
+					log.Errorf("validator %d attesting at slot %d is out of the validator list", valIdx, slot)
+					continue
 				}
 
-				if slotInEpoch(slot, p.baseMetrics.CurrentState.Epoch) {
+				if attInCurrentEpoch {
 					p.baseMetrics.CurrentNumAttestingVals[valIdx] = true
 				}
 
-				// we are only counting rewards at NextState
-				attesterBaseReward := p.GetBaseReward(valIdx, p.baseMetrics.NextState.Validators[valIdx].EffectiveBalance, p.baseMetrics.NextState.TotalActiveBalance)
+				attesterBaseReward := baseRewardPerInc * (p.baseMetrics.NextState.Validators[valIdx].EffectiveBalance / spec.EffectiveBalanceInc)
 
 				new := false
-				if participationFlags[spec.AttSourceFlagIndex] && !epochParticipation[valIdx][spec.AttSourceFlagIndex] { // source
-					attReward += attesterBaseReward * spec.TimelySourceWeight
-					epochParticipation[valIdx][spec.AttSourceFlagIndex] = true
-					new = true
-				}
-				if participationFlags[spec.AttTargetFlagIndex] && !epochParticipation[valIdx][spec.AttTargetFlagIndex] { // target
-					attReward += attesterBaseReward * spec.TimelyTargetWeight
-					epochParticipation[valIdx][spec.AttTargetFlagIndex] = true
-					new = true
-				}
-				if participationFlags[spec.AttHeadFlagIndex] && !epochParticipation[valIdx][spec.AttHeadFlagIndex] { // head
-					attReward += attesterBaseReward * spec.TimelyHeadWeight
-					epochParticipation[valIdx][spec.AttHeadFlagIndex] = true
-					new = true
+				for flagIndex, flagWeight := range spec.ParticipatingFlagsWeight {
+					if participationFlags[flagIndex] && !epochParticipation[valIdx][flagIndex] {
+						attReward += attesterBaseReward * phase0.Gwei(flagWeight)
+						epochParticipation[valIdx][flagIndex] = true
+						new = true
+					}
 				}
+
+				block.VotesIncluded += 1
 				if new {
 					block.NewVotesIncluded += 1
 				}
 			}
 
 			// only process rewards for blocks in NextState
-			if block.Slot >= phase0.Slot(p.baseMetrics.NextState.Epoch)*spec.SlotsPerEpoch {
-				denominator := phase0.Gwei((spec.WeightDenominator - spec.ProposerWeight) * spec.WeightDenominator / spec.ProposerWeight)
+			if blockInNextState {
 				attReward = attReward / denominator
 
 				p.baseMetrics.MaxBlockRewards[block.ProposerIndex] += attReward
 				block.ManualReward += attReward
 			}
+		}
+	}
+}
 
+// Computes the actual rewards and penalties of every validator from CurrentState to NextState:
+// flag and inactivity deltas applied at the epoch transition (on top of CurrentState),
+// plus the sync committee, proposer and slashing balance changes of NextState blocks
+func (p *AltairMetrics) ProcessRewardComponents() {
+	p.processFlagDeltas()
+	p.processSyncCommitteeDeltas()
+	p.processProposerDeltas()
+	p.processSlashingDeltas()
+
+	mismatches := 0
+	for valIdx, components := range p.RewardComponents {
+		if components.Total() != p.baseMetrics.EpochReward(phase0.ValidatorIndex(valIdx)) {
+			mismatches += 1
 		}
+	}
+	if mismatches > 0 {
+		log.Warnf("epoch %d: reward components of %d validators do not add up to the balance delta", p.baseMetrics.NextState.Epoch, mismatches)
+	}
+}
 
+// whether the validator is an unslashed participant of the given flag in the PrevState epoch
+func (p AltairMetrics) isUnslashedParticipating(valIdx int, flagIndex int) bool {
+	validator := p.baseMetrics.CurrentState.Validators[valIdx]
+	return !validator.Slashed &&
+		spec.IsActive(*validator, p.baseMetrics.PrevState.Epoch) &&
+		p.baseMetrics.CurrentState.PrevEpochCorrectFlags[flagIndex][valIdx]
+}
+
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/beacon-chain.md#get_flag_index_deltas
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/beacon-chain.md#modified-get_inactivity_penalty_deltas
+func (p *AltairMetrics) processFlagDeltas() {
+	state := p.baseMetrics.CurrentState // the epoch transition is applied on top of CurrentState
+	prevEpoch := p.baseMetrics.PrevState.Epoch
+	inLeak := p.baseMetrics.FinalityDelay() > spec.MinEpochsToInactivityPenalty
+
+	baseRewardPerInc := p.GetBaseRewardPerInc(state.TotalActiveBalance)
+	activeInc := state.TotalActiveBalance / spec.EffectiveBalanceInc
+
+	var participatingInc [3]phase0.Gwei
+	for flagIndex := range participatingInc {
+		participatingBalance := phase0.Gwei(0)
+		for valIdx, validator := range state.Validators {
+			if p.isUnslashedParticipating(valIdx, flagIndex) {
+				participatingBalance += validator.EffectiveBalance
+			}
+		}
+		if participatingBalance < spec.EffectiveBalanceInc {
+			participatingBalance = spec.EffectiveBalanceInc
+		}
+		participatingInc[flagIndex] = participatingBalance / spec.EffectiveBalanceInc
+	}
+
+	for valIdx, validator := range state.Validators {
+		if valIdx >= len(p.RewardComponents) {
+			break
+		}
+		eligible := spec.IsActive(*validator, prevEpoch) ||
+			(validator.Slashed && prevEpoch+1 < validator.WithdrawableEpoch)
+		if !eligible {
+			continue
+		}
+
+		baseReward := baseRewardPerInc * (validator.EffectiveBalance / spec.EffectiveBalanceInc)
+		var deltas [3]int64
+		for flagIndex, flagWeight := range spec.ParticipatingFlagsWeight {
+			if p.isUnslashedParticipating(valIdx, flagIndex) {
+				if !inLeak {
+					rewardNumerator := baseReward * phase0.Gwei(flagWeight) * participatingInc[flagIndex]
+					deltas[flagIndex] = int64(rewardNumerator / (activeInc * spec.WeightDenominator))
+				}
+			} else if flagIndex != spec.AttHeadFlagIndex {
+				deltas[flagIndex] = -int64(baseReward * phase0.Gwei(flagWeight) / spec.WeightDenominator)
+			}
+		}
+
+		components := &p.RewardComponents[valIdx]
+		components.SourceReward = deltas[spec.AttSourceFlagIndex]
+		components.TargetReward = deltas[spec.AttTargetFlagIndex]
+		components.HeadReward = deltas[spec.AttHeadFlagIndex]
+
+		// inactivity scores are updated at the same epoch transition, before applying the penalties
+		if !p.isUnslashedParticipating(valIdx, spec.AttTargetFlagIndex) && valIdx < len(p.baseMetrics.NextState.InactivityScores) {
+			penaltyNumerator := uint64(validator.EffectiveBalance) * p.baseMetrics.NextState.InactivityScores[valIdx]
+			penaltyDenominator := uint64(spec.InactivityScoreBias) * state.InactivityPenaltyQuotient()
+			components.InactivityReward = -int64(penaltyNumerator / penaltyDenominator)
+		}
+	}
+}
+
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/beacon-chain.md#sync-aggregate-processing
+func (p *AltairMetrics) processSyncCommitteeDeltas() {
+	state := p.baseMetrics.NextState
+	if len(state.SyncCommittee.Pubkeys) == 0 {
+		return
+	}
+
+	totalActiveInc := state.TotalActiveBalance / spec.EffectiveBalanceInc
+	totalBaseRewards := p.GetBaseRewardPerInc(state.TotalActiveBalance) * totalActiveInc
+	maxParticipantRewards := totalBaseRewards * phase0.Gwei(spec.SyncRewardWeight) / phase0.Gwei(spec.WeightDenominator) / spec.SlotsPerEpoch
+	participantReward := int64(maxParticipantRewards / phase0.Gwei(spec.SyncCommitteeSize)) // this is the participantReward for a single slot
+
+	pubkeyIndices := state.PubkeyIndices()
+	committee := make([]int, len(state.SyncCommittee.Pubkeys))
+	for position, pubkey := range state.SyncCommittee.Pubkeys {
+		committee[position] = -1
+		if valIdx, ok := pubkeyIndices[pubkey]; ok && int(valIdx) < len(p.RewardComponents) {
+			committee[position] = int(valIdx)
+		}
+	}
+
+	for _, block := range state.Blocks {
+		if !block.Proposed || block.SyncAggregate == nil {
+			continue
+		}
+		for position, valIdx := range committee {
+			if valIdx < 0 {
+				continue
+			}
+			if block.SyncAggregate.SyncCommitteeBits.BitAt(uint64(position)) {
+				p.RewardComponents[valIdx].SyncReward += participantReward
+			} else {
+				p.RewardComponents[valIdx].SyncReward -= participantReward
+			}
+		}
+	}
+}
+
+// Proposer and whistleblower rewards of NextState blocks, prioritizing the Beacon API rewards when available
+func (p *AltairMetrics) processProposerDeltas() {
+	apiRewards := make(map[phase0.ValidatorIndex]spec.BlockRewardsContent)
+	proposers := make(map[phase0.ValidatorIndex]bool)
+
+	for _, block := range p.baseMetrics.NextState.Blocks {
+		if !block.Proposed {
+			continue
+		}
+		proposers[block.ProposerIndex] = true
+		if block.Reward.Data.Total > 0 {
+			reward := apiRewards[block.ProposerIndex]
+			reward.Attestations += block.Reward.Data.Attestations
+			reward.SyncAggregate += block.Reward.Data.SyncAggregate
+			reward.ProposerSlashings += block.Reward.Data.ProposerSlashings
+			reward.AttesterSlashings += block.Reward.Data.AttesterSlashings
+			apiRewards[block.ProposerIndex] = reward
+		}
+	}
+
+	for valIdx := range proposers {
+		if int(valIdx) >= len(p.RewardComponents) {
+			continue
+		}
+		components := &p.RewardComponents[valIdx]
+		if reward, ok := apiRewards[valIdx]; ok {
+			components.ProposerReward += int64(reward.Attestations + reward.SyncAggregate)
+			components.SlashingReward += int64(reward.ProposerSlashings + reward.AttesterSlashings)
+			continue
+		}
+		components.ProposerReward += int64(p.baseMetrics.MaxBlockRewards[valIdx])
+		components.SlashingReward += int64(p.baseMetrics.MaxSlashingRewards[valIdx])
+	}
+}
+
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/bellatrix/beacon-chain.md#modified-slash_validator
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/bellatrix/beacon-chain.md#slashings
+func (p *AltairMetrics) processSlashingDeltas() {
+	nextState := p.baseMetrics.NextState
+	currentState := p.baseMetrics.CurrentState
+
+	// initial penalty for the validators slashed in NextState blocks
+	slashed := make(map[phase0.ValidatorIndex]bool)
+	for _, block := range nextState.Blocks {
+		for _, valIdx := range getSlashedValidators(block) {
+			if slashed[valIdx] || int(valIdx) >= len(p.RewardComponents) {
+				continue
+			}
+			if int(valIdx) < len(currentState.Validators) && currentState.Validators[valIdx].Slashed {
+				continue // already slashed before, not slashable again
+			}
+			slashed[valIdx] = true
+			penalty := nextState.Validators[valIdx].EffectiveBalance / nextState.MinSlashingPenaltyQuotient()
+			p.RewardComponents[valIdx].SlashingReward -= int64(penalty)
+		}
+	}
+
+	// correlation penalty applied at the epoch transition, half way to the withdrawable epoch
+	totalSlashings := phase0.Gwei(0)
+	for _, slashing := range currentState.Slashings {
+		totalSlashings += slashing
+	}
+	adjustedTotalSlashing := totalSlashings * currentState.ProportionalSlashingMultiplier()
+	if adjustedTotalSlashing > currentState.TotalActiveBalance {
+		adjustedTotalSlashing = currentState.TotalActiveBalance
+	}
+	if currentState.TotalActiveBalance == 0 {
+		return
+	}
+
+	for valIdx, validator := range currentState.Validators {
+		if valIdx >= len(p.RewardComponents) {
+			break
+		}
+		if validator.Slashed && currentState.Epoch+spec.EpochsPerSlashingsVector/2 == validator.WithdrawableEpoch {
+			penaltyNumerator := validator.EffectiveBalance / spec.EffectiveBalanceInc * adjustedTotalSlashing
+			penalty := penaltyNumerator / currentState.TotalActiveBalance * spec.EffectiveBalanceInc
+			p.RewardComponents[valIdx].SlashingReward -= int64(penalty)
+		}
 	}
 }
 
@@ -332,6 +540,12 @@ func (p AltairMetrics) GetMaxReward(valIdx phase0.ValidatorIndex) (spec.Validato
 		InSyncCommittee:      inSyncCommitte,
 		InclusionDelay:       p.baseMetrics.InclusionDelays[valIdx],
 	}
+	if int(valIdx) < len(p.RewardComponents) {
+		result.RewardComponents = p.RewardComponents[valIdx]
+	}
+	if int(valIdx) < len(p.baseMetrics.NextState.InactivityScores) {
+		result.InactivityScore = p.baseMetrics.NextState.InactivityScores[valIdx]
+	}
 	return result, nil
 
 }
@@ -357,12 +571,12 @@ func (p AltairMetrics) GetInclusionDelay(attestation phase0.Attestation, include
 	return int(includedInBlock.Slot - attestation.Data.Slot)
 }
 
-func (p AltairMetrics) getParticipationFlags(attestation phase0.Attestation, includedInBlock spec.AgnosticBlock) [3]bool {
+func (p AltairMetrics) getParticipationFlags(attestation phase0.Attestation, includedInBlock spec.AgnosticBlock) ([3]bool, error) {
 	var result [3]bool
 
 	justifiedCheckpoint, err := p.GetJustifiedRootfromSlot(attestation.Data.Slot)
 	if err != nil {
-		log.Fatalf("error getting justified checkpoint: %s", err)
+		return result, fmt.Errorf("error getting justified checkpoint: %s", err)
 	}
 
 	inclusionDelay := p.GetInclusionDelay(attestation, includedInBlock)
@@ -384,7 +598,7 @@ func (p AltairMetrics) getParticipationFlags(attestation phase0.Attestation, inc
 		result[spec.AttHeadFlagIndex] = true
 	}
 
-	return result
+	return result, nil
 }
 
 func (p AltairMetrics) isFlagPossible(valIdx phase0.ValidatorIndex, flagIndex int) bool {
@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/spec"
+	"github.com/migalabs/goteth/pkg/spec/spectest"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/assert"
+)
+
+// Includes the votes of the given validators in the block at the given slot
+func includeVotes(state *spec.AgnosticState, slot phase0.Slot, attSlot phase0.Slot, valIdxs ...int) {
+	bits := bitfield.NewBitlist(spectest.CommitteeSize)
+	for _, valIdx := range valIdxs {
+		bits.SetBitAt(uint64(valIdx%spectest.CommitteeSize), true)
+	}
+	block := state.Blocks[slot%spec.SlotsPerEpoch]
+	block.Attestations = append(block.Attestations, &phase0.Attestation{
+		AggregationBits: bits,
+		Data:            &phase0.AttestationData{Slot: attSlot},
+	})
+}
+
+// PrevState epoch 1, CurrentState epoch 2 and NextState epoch 3, with votes to the PrevState epoch:
+// validators 0 and 1 included with delay 1 (validator 0 again later), 24 and 25 included in CurrentState with delay 6
+func syntheticInclusionBundle() StateMetricsBase {
+	numValidators := spec.SlotsPerEpoch * spectest.CommitteeSize
+	base := StateMetricsBase{
+		PrevState:    spectest.State(1, numValidators),
+		CurrentState: spectest.State(2, numValidators),
+		NextState:    spectest.State(3, numValidators),
+	}
+	base.InclusionDelays = make([]int, numValidators)
+
+	firstSlot := phase0.Slot(spec.SlotsPerEpoch)
+	includeVotes(base.PrevState, firstSlot+1, firstSlot, 0, 1)
+	includeVotes(base.PrevState, firstSlot+8, firstSlot, 0)
+	includeVotes(base.CurrentState, 2*firstSlot+2, firstSlot+12, 24, 25)
+	return base
+}
+
+func TestAltairInclusionDelays(t *testing.T) {
+	metrics := AltairMetrics{}
+	metrics.baseMetrics = syntheticInclusionBundle()
+
+	metrics.ProcessInclusionDelays()
+
+	delays := metrics.baseMetrics.InclusionDelays
+	assert.Equal(t, 1, delays[0]) // first inclusion wins
+	assert.Equal(t, 1, delays[1])
+	assert.Equal(t, 6, delays[24]) // included in the next epoch
+	assert.Equal(t, 6, delays[25])
+}
+
+func TestAltairMissedInclusionDelays(t *testing.T) {
+	metrics := AltairMetrics{}
+	metrics.baseMetrics = syntheticInclusionBundle()
+
+	metrics.ProcessInclusionDelays()
+
+	// not included: one more than the maximum delay
+	delays := metrics.baseMetrics.InclusionDelays
+	for valIdx := 2; valIdx < len(delays); valIdx++ {
+		if valIdx == 24 || valIdx == 25 {
+			continue
+		}
+		assert.Equal(t, spec.SlotsPerEpoch+1, delays[valIdx], "validator %d was not included", valIdx)
+	}
+}
+
+func TestDenebInclusionDelays(t *testing.T) {
+	metrics := DenebMetrics{}
+	metrics.baseMetrics = syntheticInclusionBundle()
+
+	metrics.ProcessInclusionDelays()
+
+	delays := metrics.baseMetrics.InclusionDelays
+	assert.Equal(t, 1, delays[0])
+	assert.Equal(t, 1, delays[1])
+	assert.Equal(t, 6, delays[24])
+	assert.Equal(t, 6, delays[25])
+
+	// not included: the vote could have been included until the end of the next epoch
+	for valIdx := 2; valIdx < len(delays); valIdx++ {
+		if valIdx == 24 || valIdx == 25 {
+			continue
+		}
+		attSlot := valIdx / spectest.CommitteeSize
+		expected := 2*spec.SlotsPerEpoch - attSlot
+		assert.Equal(t, expected, delays[valIdx], "validator %d was not included", valIdx)
+	}
+}
+
+// Two validators of 32 ETH from epoch 9 to 11: NextState balances are set by each case
+func syntheticRewardsMetrics(finalizedEpoch phase0.Epoch) *AltairMetrics {
+	states := []*spec.AgnosticState{spectest.State(9, 2), spectest.State(10, 2), spectest.State(11, 2)}
+	for _, state := range states {
+		state.FinalizedCheckpoint.Epoch = finalizedEpoch
+		state.Withdrawals = make([]phase0.Gwei, 2)
+		state.Deposits = make([]phase0.Gwei, 2)
+		state.InactivityScores = make([]uint64, 2)
+	}
+
+	metrics := &AltairMetrics{}
+	metrics.InitBundle(states[2], states[1], states[0])
+	return metrics
+}
+
+// Base reward of 3162272 with 64 ETH active: source and head flags are worth 691747, target 1284673
+func TestAltairRewardComponentsTotal(t *testing.T) {
+	t.Run("inactivity leak", func(t *testing.T) {
+		metrics := syntheticRewardsMetrics(0) // finality delay of 9 epochs
+		metrics.baseMetrics.CurrentState.PrevEpochCorrectFlags = [][]bool{{true, false}, {true, false}, {true, false}}
+		metrics.baseMetrics.NextState.InactivityScores[1] = 100
+
+		// no flag rewards in the leak, validator 1 loses source, target and inactivity (32 ETH * 100 / (4 * 3 * 2^24))
+		metrics.baseMetrics.NextState.Balances[1] -= 691747 + 1284673 + 15894
+
+		metrics.ProcessRewardComponents()
+
+		for valIdx, components := range metrics.RewardComponents {
+			assert.Equal(t, metrics.baseMetrics.EpochReward(phase0.ValidatorIndex(valIdx)), components.Total(), "validator %d", valIdx)
+		}
+		assert.Equal(t, int64(-15894), metrics.RewardComponents[1].InactivityReward)
+	})
+
+	t.Run("slashing", func(t *testing.T) {
+		metrics := syntheticRewardsMetrics(9)
+		nextState := metrics.baseMetrics.NextState
+		nextState.Blocks[1].ProposerSlashings = []*phase0.ProposerSlashing{proposerSlashing(1)}
+
+		// validator 0 proposes every block and gets the whistleblower reward (32 ETH / 512),
+		// validator 1 is slashed with the initial penalty (32 ETH / 64)
+		nextState.Balances[0] += 2668167 + 62500000
+		nextState.Balances[1] += 2668167
+		nextState.Balances[1] -= 500000000
+
+		metrics.ProcessSlashings()
+		metrics.ProcessRewardComponents()
+
+		for valIdx, components := range metrics.RewardComponents {
+			assert.Equal(t, metrics.baseMetrics.EpochReward(phase0.ValidatorIndex(valIdx)), components.Total(), "validator %d", valIdx)
+		}
+		assert.Equal(t, int64(-500000000), metrics.RewardComponents[1].SlashingReward)
+	})
+}
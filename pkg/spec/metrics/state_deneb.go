@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -35,6 +36,7 @@ func (p *DenebMetrics) InitBundle(nextState *spec.AgnosticState,
 	p.baseMetrics.InclusionDelays = make([]int, len(p.baseMetrics.NextState.Validators))
 	p.baseMetrics.MaxAttesterRewards = make(map[phase0.ValidatorIndex]phase0.Gwei)
 	p.MaxSyncCommitteeRewards = make(map[phase0.ValidatorIndex]phase0.Gwei)
+	p.RewardComponents = make([]spec.RewardComponents, len(p.baseMetrics.NextState.Validators))
 	p.baseMetrics.CurrentNumAttestingVals = make([]bool, len(currentState.Validators))
 }
 
@@ -42,11 +44,14 @@ func (p *DenebMetrics) PreProcessBundle() {
 
 	if !p.baseMetrics.PrevState.EmptyStateRoot() && !p.baseMetrics.CurrentState.EmptyStateRoot() {
 		// block rewards
-		// p.ProcessAttestations()
-		// p.ProcessInclusionDelays()
+		if p.baseMetrics.NextState.EpochStructs.HasCommittees() {
+			p.ProcessAttestations()
+			p.ProcessInclusionDelays()
+		}
 
 		p.ProcessSlashings()
 		p.ProcessSyncAggregates()
+		p.ProcessRewardComponents()
 
 		p.GetMaxFlagIndexDeltas()
 		p.GetMaxSyncComReward()
@@ -55,126 +60,11 @@ func (p *DenebMetrics) PreProcessBundle() {
 
 // https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/beacon-chain.md#modified-process_attestation
 func (p DenebMetrics) ProcessAttestations() {
-
-	if p.baseMetrics.CurrentState.Blocks == nil { // only process attestations when CurrentState available
-		return
-	}
-
-	currentEpochParticipation := make([][]bool, len(p.baseMetrics.CurrentState.Validators))
-	nextEpochParticipation := make([][]bool, len(p.baseMetrics.NextState.Validators))
-
-	blockList := p.baseMetrics.CurrentState.Blocks
-	blockList = append(
-		blockList,
-		p.baseMetrics.NextState.Blocks...)
-
-	for _, block := range blockList {
-
-		for _, attestation := range block.Attestations {
-
-			attReward := phase0.Gwei(0)
-			slot := attestation.Data.Slot
-			epochParticipation := nextEpochParticipation
-			if slotInEpoch(slot, p.baseMetrics.CurrentState.Epoch) {
-				epochParticipation = currentEpochParticipation
-			}
-
-			if slot < phase0.Slot(p.baseMetrics.CurrentState.Epoch)*spec.SlotsPerEpoch {
-				continue
-			}
-
-			participationFlags := p.getParticipationFlags(*attestation, *block)
-
-			committeIndex := attestation.Data.Index
-
-			attestingIndices := attestation.AggregationBits.BitIndices()
-
-			for _, idx := range attestingIndices {
-				block.VotesIncluded += 1
-
-				valIdx, err := p.GetValidatorFromCommitteeIndex(slot, committeIndex, idx)
-				if err != nil {
-					log.Fatalf("error processing attestations at block %d: %s", block.Slot, err)
-				}
-				if epochParticipation[valIdx] == nil {
-					epochParticipation[valIdx] = make([]bool, len(spec.ParticipatingFlagsWeight))
-				}
-
-				if slotInEpoch(slot, p.baseMetrics.CurrentState.Epoch) {
-					p.baseMetrics.CurrentNumAttestingVals[valIdx] = true
-				}
-
-				// we are only counting rewards at NextState
-				attesterBaseReward := p.GetBaseReward(valIdx, p.baseMetrics.NextState.Validators[valIdx].EffectiveBalance, p.baseMetrics.NextState.TotalActiveBalance)
-
-				new := false
-				if participationFlags[spec.AttSourceFlagIndex] && !epochParticipation[valIdx][spec.AttSourceFlagIndex] { // source
-					attReward += attesterBaseReward * spec.TimelySourceWeight
-					epochParticipation[valIdx][spec.AttSourceFlagIndex] = true
-					new = true
-				}
-				if participationFlags[spec.AttTargetFlagIndex] && !epochParticipation[valIdx][spec.AttTargetFlagIndex] { // target
-					attReward += attesterBaseReward * spec.TimelyTargetWeight
-					epochParticipation[valIdx][spec.AttTargetFlagIndex] = true
-					new = true
-				}
-				if participationFlags[spec.AttHeadFlagIndex] && !epochParticipation[valIdx][spec.AttHeadFlagIndex] { // head
-					attReward += attesterBaseReward * spec.TimelyHeadWeight
-					epochParticipation[valIdx][spec.AttHeadFlagIndex] = true
-					new = true
-				}
-				if new {
-					block.NewVotesIncluded += 1
-				}
-			}
-
-			// only process rewards for blocks in NextState
-			if block.Slot >= phase0.Slot(p.baseMetrics.NextState.Epoch)*spec.SlotsPerEpoch {
-				denominator := phase0.Gwei((spec.WeightDenominator - spec.ProposerWeight) * spec.WeightDenominator / spec.ProposerWeight)
-				attReward = attReward / denominator
-
-				p.baseMetrics.MaxBlockRewards[block.ProposerIndex] += attReward
-				block.ManualReward += attReward
-			}
-
-		}
-
-	}
+	p.processAttestations(p.getParticipationFlags)
 }
 
 func (p *DenebMetrics) ProcessInclusionDelays() {
-	for _, block := range append(p.baseMetrics.PrevState.Blocks, p.baseMetrics.CurrentState.Blocks...) {
-		// we assume the blocks are in order asc
-		for _, attestation := range block.Attestations {
-			attSlot := attestation.Data.Slot
-			// Calculate inclusion delays only for attestations corresponding to slots from the previous epoch
-			attSlotNotInPrevEpoch := attSlot < phase0.Slot(p.baseMetrics.PrevState.Epoch)*spec.SlotsPerEpoch || attSlot >= phase0.Slot(p.baseMetrics.CurrentState.Epoch)*spec.SlotsPerEpoch
-			if attSlotNotInPrevEpoch {
-				continue
-			}
-			inclusionDelay := p.GetInclusionDelay(*attestation, *block)
-			committeIndex := attestation.Data.Index
-
-			attestingIndices := attestation.AggregationBits.BitIndices()
-
-			for _, idx := range attestingIndices {
-				valIdx, err := p.GetValidatorFromCommitteeIndex(attSlot, committeIndex, idx)
-				if err != nil {
-					log.Fatalf("error processing attestations at block %d: %s", block.Slot, err)
-				}
-
-				if p.baseMetrics.InclusionDelays[valIdx] == 0 {
-					p.baseMetrics.InclusionDelays[valIdx] = inclusionDelay
-				}
-			}
-		}
-	}
-
-	for valIdx, inclusionDelay := range p.baseMetrics.InclusionDelays {
-		if inclusionDelay == 0 {
-			p.baseMetrics.InclusionDelays[valIdx] = p.maxInclusionDelay(phase0.ValidatorIndex(valIdx)) + 1
-		}
-	}
+	p.processInclusionDelays(p.maxInclusionDelay)
 }
 
 // https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/beacon-chain.md#get_flag_index_deltas
@@ -206,12 +96,12 @@ func (p DenebMetrics) GetMaxFlagIndexDeltas() {
 	}
 }
 
-func (p DenebMetrics) getParticipationFlags(attestation phase0.Attestation, includedInBlock spec.AgnosticBlock) [3]bool {
+func (p DenebMetrics) getParticipationFlags(attestation phase0.Attestation, includedInBlock spec.AgnosticBlock) ([3]bool, error) {
 	var result [3]bool
 
 	justifiedCheckpoint, err := p.GetJustifiedRootfromSlot(attestation.Data.Slot)
 	if err != nil {
-		log.Fatalf("error getting justified checkpoint: %s", err)
+		return result, fmt.Errorf("error getting justified checkpoint: %s", err)
 	}
 
 	inclusionDelay := p.GetInclusionDelay(attestation, includedInBlock)
@@ -241,7 +131,7 @@ func (p DenebMetrics) getParticipationFlags(attestation phase0.Attestation, incl
 		result[2] = true
 	}
 
-	return result
+	return result, nil
 }
 
 func (p DenebMetrics) isFlagPossible(valIdx phase0.ValidatorIndex, flagIndex int) bool {
@@ -8,29 +8,20 @@ import (
 	"github.com/migalabs/goteth/pkg/spec"
 )
 
-func (p AltairMetrics) GetValidatorFromCommitteeIndex(slot phase0.Slot, committeeIndex phase0.CommitteeIndex, idx int) (phase0.ValidatorIndex, error) {
-	if slot >= phase0.Slot(p.baseMetrics.PrevState.Epoch)*spec.SlotsPerEpoch &&
-		slot < phase0.Slot(p.baseMetrics.CurrentState.Epoch)*spec.SlotsPerEpoch {
-		// slot in PrevEpoch
-		valList := p.baseMetrics.PrevState.EpochStructs.GetValList(slot, committeeIndex)
-		return valList[idx], nil
-	}
-
-	if slot >= phase0.Slot(p.baseMetrics.CurrentState.Epoch)*spec.SlotsPerEpoch &&
-		slot < phase0.Slot(p.baseMetrics.NextState.Epoch)*spec.SlotsPerEpoch {
-		// slot in CurrentEpoch
-		valList := p.baseMetrics.CurrentState.EpochStructs.GetValList(slot, committeeIndex)
-		return valList[idx], nil
-	}
-
-	if slot >= phase0.Slot(p.baseMetrics.NextState.Epoch)*spec.SlotsPerEpoch &&
-		slot < phase0.Slot(p.baseMetrics.NextState.Epoch+1)*spec.SlotsPerEpoch {
-		// slot in NextEpoch
-		valList := p.baseMetrics.NextState.EpochStructs.GetValList(slot, committeeIndex)
-		return valList[idx], nil
+// Returns the validators that voted in the attestation, using the committees of the state its slot belongs to
+func (s StateMetricsBase) GetAttestingIndices(attestation *phase0.Attestation) ([]phase0.ValidatorIndex, error) {
+	slot := attestation.Data.Slot
+
+	switch {
+	case slotInEpoch(slot, s.PrevState.Epoch):
+		return s.PrevState.EpochStructs.GetAttestingIndices(attestation)
+	case slotInEpoch(slot, s.CurrentState.Epoch):
+		return s.CurrentState.EpochStructs.GetAttestingIndices(attestation)
+	case slotInEpoch(slot, s.NextState.Epoch):
+		return s.NextState.EpochStructs.GetAttestingIndices(attestation)
 	}
 
-	return 0, fmt.Errorf("could not get validator from any epoch: slot %d, committee %d, index %d", slot, committeeIndex, idx)
+	return nil, fmt.Errorf("could not get committees from any epoch: slot %d, committee %d", slot, attestation.Data.Index)
 }
 
 func (p AltairMetrics) GetJustifiedRootfromSlot(slot phase0.Slot) (phase0.Root, error) {
@@ -97,6 +88,39 @@ func (s StateMetricsBase) GetBestInclusionDelay(slot phase0.Slot) (int, error) {
 	return int(slot - minSlot), nil
 }
 
+// Returns the validators slashed by the proposer and attester slashings of the block
+func getSlashedValidators(block *spec.AgnosticBlock) []phase0.ValidatorIndex {
+	slashings := getBlockSlashings(block)
+	slashedIdxs := make([]phase0.ValidatorIndex, 0, len(slashings))
+	for _, slashing := range slashings {
+		slashedIdxs = append(slashedIdxs, slashing.SlashedValidator)
+	}
+	return slashedIdxs
+}
+
+// One item per validator slashed in the block, rewards are not filled
+func getBlockSlashings(block *spec.AgnosticBlock) []spec.Slashing {
+	slashings := make([]spec.Slashing, 0)
+	newSlashing := func(valIdx phase0.ValidatorIndex, slashingType spec.SlashingType) spec.Slashing {
+		return spec.Slashing{
+			SlashedValidator: valIdx,
+			Slot:             block.Slot,
+			Epoch:            phase0.Epoch(block.Slot / spec.SlotsPerEpoch),
+			SlashingType:     slashingType,
+			Whistleblower:    block.ProposerIndex,
+		}
+	}
+	for _, attSlashing := range block.AttesterSlashings {
+		for _, valIdx := range spec.SlashingIntersection(attSlashing.Attestation1.AttestingIndices, attSlashing.Attestation2.AttestingIndices) {
+			slashings = append(slashings, newSlashing(valIdx, spec.ATTESTER_SLASHING))
+		}
+	}
+	for _, proposerSlashing := range block.ProposerSlashings {
+		slashings = append(slashings, newSlashing(proposerSlashing.SignedHeader1.Message.ProposerIndex, spec.PROPOSER_SLASHING))
+	}
+	return slashings
+}
+
 func countTrue(arr []bool) int {
 	result := 0
 
@@ -18,3 +18,24 @@ func SlashingIntersection(set1 []uint64, set2 []uint64) []phase0.ValidatorIndex
 	return res
 
 }
+
+type SlashingType uint8
+
+const (
+	PROPOSER_SLASHING SlashingType = iota
+	ATTESTER_SLASHING
+)
+
+// Validator slashed by an operation included in a block
+type Slashing struct {
+	SlashedValidator    phase0.ValidatorIndex
+	Slot                phase0.Slot
+	Epoch               phase0.Epoch
+	SlashingType        SlashingType
+	Whistleblower       phase0.ValidatorIndex // the block proposer, as per spec
+	WhistleblowerReward phase0.Gwei           // reward credited to the whistleblower for this validator
+}
+
+func (f Slashing) Type() ModelType {
+	return SlashingModel
+}
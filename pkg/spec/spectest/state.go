@@ -0,0 +1,65 @@
+// Package spectest builds synthetic states for the tests of the packages that process them
+package spectest
+
+import (
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/migalabs/goteth/pkg/spec"
+)
+
+const (
+	CommitteeSize    = 2 // one committee per slot, validator v attests at slot v / CommitteeSize of the epoch
+	EffectiveBalance = 32 * spec.EffectiveBalanceInc
+)
+
+// Altair state at the end of the epoch: every validator is active with 32 ETH and got every flag in the previous epoch,
+// every slot has a proposed block without operations and every validator has a committee
+func State(epoch phase0.Epoch, numValidators int) *spec.AgnosticState {
+	state := &spec.AgnosticState{
+		Version:            eth2spec.DataVersionAltair,
+		StateRoot:          &phase0.Root{byte(epoch)},
+		Epoch:              epoch,
+		Slot:               phase0.Slot(epoch+1)*spec.SlotsPerEpoch - 1,
+		Validators:         make([]*phase0.Validator, numValidators),
+		Balances:           make([]phase0.Gwei, numValidators),
+		TotalActiveBalance: EffectiveBalance * phase0.Gwei(numValidators),
+		AttestingBalance:   make([]phase0.Gwei, 3),
+		Blocks:             make([]*spec.AgnosticBlock, spec.SlotsPerEpoch),
+	}
+	if epoch >= 2 {
+		state.FinalizedCheckpoint.Epoch = epoch - 2
+	}
+	for i := range state.Validators {
+		state.Validators[i] = &phase0.Validator{
+			EffectiveBalance:  EffectiveBalance,
+			ExitEpoch:         spec.FarFutureEpoch,
+			WithdrawableEpoch: spec.FarFutureEpoch,
+		}
+		state.Balances[i] = EffectiveBalance
+	}
+	state.PrevEpochCorrectFlags = make([][]bool, 3)
+	for flagIndex := range state.PrevEpochCorrectFlags {
+		state.PrevEpochCorrectFlags[flagIndex] = make([]bool, numValidators)
+		for i := range state.PrevEpochCorrectFlags[flagIndex] {
+			state.PrevEpochCorrectFlags[flagIndex][i] = true
+		}
+	}
+	for i := range state.Blocks {
+		state.Blocks[i] = &spec.AgnosticBlock{Slot: phase0.Slot(epoch)*spec.SlotsPerEpoch + phase0.Slot(i), Proposed: true}
+	}
+
+	committees := make([]*api.BeaconCommittee, 0, spec.SlotsPerEpoch)
+	for i := 0; i < spec.SlotsPerEpoch; i++ {
+		validators := make([]phase0.ValidatorIndex, 0, CommitteeSize)
+		for j := i * CommitteeSize; j < (i+1)*CommitteeSize && j < numValidators; j++ {
+			validators = append(validators, phase0.ValidatorIndex(j))
+		}
+		committees = append(committees, &api.BeaconCommittee{
+			Slot:       phase0.Slot(epoch)*spec.SlotsPerEpoch + phase0.Slot(i),
+			Validators: validators,
+		})
+	}
+	state.EpochStructs.AddBeaconCommittees(committees)
+	return state
+}
@@ -8,35 +8,44 @@ import (
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
 )
 
 // This Wrapper is meant to include all common objects across Ethereum Hard Fork Specs
 type AgnosticState struct {
-	Version                    spec.DataVersion
-	GenesisTimestamp           uint64 // genesis timestamp
-	StateRoot                  *phase0.Root
-	Epoch                      phase0.Epoch                 // Epoch of the state
-	Slot                       phase0.Slot                  // Slot of the state
-	Balances                   []phase0.Gwei                // balance of each validator
-	Validators                 []*phase0.Validator          // list of validators
-	TotalActiveBalance         phase0.Gwei                  // effective balance
-	TotalActiveRealBalance     phase0.Gwei                  // real balance
-	AttestingBalance           []phase0.Gwei                // one attesting balance per flag (of the previous epoch attestations)
-	EpochStructs               EpochDuties                  // structs about beacon committees, proposers and attestation
-	PrevEpochCorrectFlags      [][]bool                     // one aray per flag
-	PrevAttestations           []*phase0.PendingAttestation // array of attestations (currently only for Phase0)
-	NumActiveVals              uint                         // number of active validators in the epoch
-	NumExitedVals              uint                         // number of exited validators in the epoch
-	NumSlashedVals             uint                         // number of slashed validators in the epoch
-	NumQueuedVals              uint                         // number of validators in the queue
-	BlockRoots                 []phase0.Root                // array of block roots at this point (8192)
-	MissedBlocks               []phase0.Slot                // blocks missed in the epoch until this point
-	SyncCommittee              altair.SyncCommittee         // list of pubkeys in the current sync committe
-	Blocks                     []*AgnosticBlock             // list of blocks in the epoch
-	Withdrawals                []phase0.Gwei                // one position per validator
-	Deposits                   []phase0.Gwei                // one per validator index
-	CurrentJustifiedCheckpoint phase0.Checkpoint            // the latest justified checkpoint
-	LatestBlockHeader          *phase0.BeaconBlockHeader
+	Version                     spec.DataVersion
+	GenesisTimestamp            uint64 // genesis timestamp
+	StateRoot                   *phase0.Root
+	Epoch                       phase0.Epoch                 // Epoch of the state
+	Slot                        phase0.Slot                  // Slot of the state
+	Balances                    []phase0.Gwei                // balance of each validator
+	Validators                  []*phase0.Validator          // list of validators
+	TotalActiveBalance          phase0.Gwei                  // effective balance
+	TotalActiveRealBalance      phase0.Gwei                  // real balance
+	AttestingBalance            []phase0.Gwei                // one attesting balance per flag (of the previous epoch attestations)
+	EpochStructs                EpochDuties                  // structs about beacon committees, proposers and attestation
+	PrevEpochCorrectFlags       [][]bool                     // one aray per flag
+	PrevAttestations            []*phase0.PendingAttestation // array of attestations (currently only for Phase0)
+	NumActiveVals               uint                         // number of active validators in the epoch
+	NumExitedVals               uint                         // number of exited validators in the epoch
+	NumSlashedVals              uint                         // number of slashed validators in the epoch
+	NumQueuedVals               uint                         // number of validators in the queue
+	BlockRoots                  []phase0.Root                // array of block roots at this point (8192)
+	MissedBlocks                []phase0.Slot                // blocks missed in the epoch until this point
+	SyncCommittee               altair.SyncCommittee         // list of pubkeys in the current sync committe
+	Blocks                      []*AgnosticBlock             // list of blocks in the epoch
+	Withdrawals                 []phase0.Gwei                // one position per validator
+	Deposits                    []phase0.Gwei                // one per validator index
+	JustificationBits           bitfield.Bitvector4          // justification status of the last 4 epochs
+	PreviousJustifiedCheckpoint phase0.Checkpoint            // the justified checkpoint before the latest one
+	CurrentJustifiedCheckpoint  phase0.Checkpoint            // the latest justified checkpoint
+	FinalizedCheckpoint         phase0.Checkpoint            // the latest finalized checkpoint
+	InactivityScores            []uint64                     // one per validator (from Altair)
+	Slashings                   []phase0.Gwei                // sum of effective balances slashed per epoch (circular)
+	LatestBlockHeader           *phase0.BeaconBlockHeader
+	Eth1Data                    *phase0.ETH1Data                           // latest eth1 data voted, deposits are included up to its deposit count
+	Eth1DepositIndex            uint64                                     // index of the next deposit to be processed
+	pubkeyIndices               map[phase0.BLSPubKey]phase0.ValidatorIndex // built once in Setup, see PubkeyIndices
 }
 
 func GetCustomState(bstate spec.VersionedBeaconState, duties EpochDuties) (AgnosticState, error) {
@@ -75,6 +84,7 @@ func (p *AgnosticState) Setup() error {
 	for i := range p.PrevEpochCorrectFlags {
 		p.PrevEpochCorrectFlags[i] = make([]bool, arrayLen)
 	}
+	p.pubkeyIndices = buildPubkeyIndices(p.Validators)
 	p.GetValsStateNums()
 	p.TotalActiveBalance = p.GetTotalActiveEffBalance()
 	p.TotalActiveRealBalance = p.GetTotalActiveRealBalance()
@@ -102,13 +112,11 @@ func (p *AgnosticState) CalculateWithdrawals() {
 func (p *AgnosticState) CalculateDeposits() {
 
 	p.Deposits = make([]phase0.Gwei, len(p.Validators))
+	pubkeyIndices := p.PubkeyIndices()
 	for _, block := range p.Blocks {
 		for _, deposit := range block.Deposits {
-
-			for valIDx, validator := range p.Validators {
-				if deposit.Data.PublicKey == validator.PublicKey {
-					p.Deposits[valIDx] += deposit.Data.Amount
-				}
+			if valIdx, ok := pubkeyIndices[deposit.Data.PublicKey]; ok {
+				p.Deposits[valIdx] += deposit.Data.Amount
 			}
 		}
 
@@ -291,6 +299,45 @@ func (p AgnosticState) EmptyStateRoot() bool {
 	return true
 }
 
+// Fork dependent penalty constants
+func (p AgnosticState) InactivityPenaltyQuotient() uint64 {
+	if p.Version == spec.DataVersionAltair {
+		return InactivityPenaltyQuotientAltair
+	}
+	return InactivityPenaltyQuotientBellatrix
+}
+
+func (p AgnosticState) MinSlashingPenaltyQuotient() phase0.Gwei {
+	if p.Version == spec.DataVersionAltair {
+		return MinSlashingPenaltyQuotientAltair
+	}
+	return MinSlashingPenaltyQuotientBellatrix
+}
+
+func (p AgnosticState) ProportionalSlashingMultiplier() phase0.Gwei {
+	if p.Version == spec.DataVersionAltair {
+		return ProportionalSlashingMultiplierAltair
+	}
+	return ProportionalSlashingMultiplierBellatrix
+}
+
+// Returns the validator index of every pubkey in the validator list
+// The map is shared by every caller and must not be modified
+func (p AgnosticState) PubkeyIndices() map[phase0.BLSPubKey]phase0.ValidatorIndex {
+	if p.pubkeyIndices == nil {
+		return buildPubkeyIndices(p.Validators) // the state was not setup
+	}
+	return p.pubkeyIndices
+}
+
+func buildPubkeyIndices(validators []*phase0.Validator) map[phase0.BLSPubKey]phase0.ValidatorIndex {
+	result := make(map[phase0.BLSPubKey]phase0.ValidatorIndex, len(validators))
+	for valIdx, validator := range validators {
+		result[validator.PublicKey] = phase0.ValidatorIndex(valIdx)
+	}
+	return result
+}
+
 // This Wrapper is meant to include all necessary data from the Phase0 Fork
 func NewPhase0State(bstate spec.VersionedBeaconState, duties EpochDuties) AgnosticState {
 
@@ -302,17 +349,23 @@ func NewPhase0State(bstate spec.VersionedBeaconState, duties EpochDuties) Agnost
 
 	phase0Obj := AgnosticState{
 
-		Version:                    bstate.Version,
-		Balances:                   balances,
-		Validators:                 bstate.Phase0.Validators,
-		EpochStructs:               duties,
-		Epoch:                      phase0.Epoch(bstate.Phase0.Slot / SlotsPerEpoch),
-		Slot:                       phase0.Slot(bstate.Phase0.Slot),
-		BlockRoots:                 bstate.Phase0.BlockRoots,
-		PrevAttestations:           bstate.Phase0.PreviousEpochAttestations,
-		GenesisTimestamp:           bstate.Phase0.GenesisTime,
-		CurrentJustifiedCheckpoint: *bstate.Phase0.CurrentJustifiedCheckpoint,
-		LatestBlockHeader:          bstate.Phase0.LatestBlockHeader,
+		Version:                     bstate.Version,
+		Balances:                    balances,
+		Validators:                  bstate.Phase0.Validators,
+		EpochStructs:                duties,
+		Epoch:                       phase0.Epoch(bstate.Phase0.Slot / SlotsPerEpoch),
+		Slot:                        phase0.Slot(bstate.Phase0.Slot),
+		BlockRoots:                  bstate.Phase0.BlockRoots,
+		PrevAttestations:            bstate.Phase0.PreviousEpochAttestations,
+		GenesisTimestamp:            bstate.Phase0.GenesisTime,
+		JustificationBits:           bstate.Phase0.JustificationBits,
+		PreviousJustifiedCheckpoint: *bstate.Phase0.PreviousJustifiedCheckpoint,
+		CurrentJustifiedCheckpoint:  *bstate.Phase0.CurrentJustifiedCheckpoint,
+		FinalizedCheckpoint:         *bstate.Phase0.FinalizedCheckpoint,
+		Slashings:                   bstate.Phase0.Slashings,
+		LatestBlockHeader:           bstate.Phase0.LatestBlockHeader,
+		Eth1Data:                    bstate.Phase0.ETH1Data,
+		Eth1DepositIndex:            bstate.Phase0.ETH1DepositIndex,
 	}
 
 	phase0Obj.Setup()
@@ -325,17 +378,24 @@ func NewPhase0State(bstate spec.VersionedBeaconState, duties EpochDuties) Agnost
 func NewAltairState(bstate spec.VersionedBeaconState, duties EpochDuties) AgnosticState {
 
 	altairObj := AgnosticState{
-		Version:                    bstate.Version,
-		Balances:                   bstate.Altair.Balances,
-		Validators:                 bstate.Altair.Validators,
-		EpochStructs:               duties,
-		Epoch:                      phase0.Epoch(bstate.Altair.Slot / SlotsPerEpoch),
-		Slot:                       bstate.Altair.Slot,
-		BlockRoots:                 bstate.Altair.BlockRoots,
-		SyncCommittee:              *bstate.Altair.CurrentSyncCommittee,
-		GenesisTimestamp:           bstate.Altair.GenesisTime,
-		CurrentJustifiedCheckpoint: *bstate.Altair.CurrentJustifiedCheckpoint,
-		LatestBlockHeader:          bstate.Altair.LatestBlockHeader,
+		Version:                     bstate.Version,
+		Balances:                    bstate.Altair.Balances,
+		Validators:                  bstate.Altair.Validators,
+		EpochStructs:                duties,
+		Epoch:                       phase0.Epoch(bstate.Altair.Slot / SlotsPerEpoch),
+		Slot:                        bstate.Altair.Slot,
+		BlockRoots:                  bstate.Altair.BlockRoots,
+		SyncCommittee:               *bstate.Altair.CurrentSyncCommittee,
+		GenesisTimestamp:            bstate.Altair.GenesisTime,
+		JustificationBits:           bstate.Altair.JustificationBits,
+		PreviousJustifiedCheckpoint: *bstate.Altair.PreviousJustifiedCheckpoint,
+		CurrentJustifiedCheckpoint:  *bstate.Altair.CurrentJustifiedCheckpoint,
+		FinalizedCheckpoint:         *bstate.Altair.FinalizedCheckpoint,
+		InactivityScores:            bstate.Altair.InactivityScores,
+		Slashings:                   bstate.Altair.Slashings,
+		LatestBlockHeader:           bstate.Altair.LatestBlockHeader,
+		Eth1Data:                    bstate.Altair.ETH1Data,
+		Eth1DepositIndex:            bstate.Altair.ETH1DepositIndex,
 	}
 
 	altairObj.Setup()
@@ -376,17 +436,24 @@ func ProcessAltairAttestations(customState *AgnosticState, prevEpochParticipatio
 func NewBellatrixState(bstate spec.VersionedBeaconState, duties EpochDuties) AgnosticState {
 
 	bellatrixObj := AgnosticState{
-		Version:                    bstate.Version,
-		Balances:                   bstate.Bellatrix.Balances,
-		Validators:                 bstate.Bellatrix.Validators,
-		EpochStructs:               duties,
-		Epoch:                      phase0.Epoch(bstate.Bellatrix.Slot / SlotsPerEpoch),
-		Slot:                       bstate.Bellatrix.Slot,
-		BlockRoots:                 bstate.Bellatrix.BlockRoots,
-		SyncCommittee:              *bstate.Bellatrix.CurrentSyncCommittee,
-		GenesisTimestamp:           bstate.Bellatrix.GenesisTime,
-		CurrentJustifiedCheckpoint: *bstate.Bellatrix.CurrentJustifiedCheckpoint,
-		LatestBlockHeader:          bstate.Bellatrix.LatestBlockHeader,
+		Version:                     bstate.Version,
+		Balances:                    bstate.Bellatrix.Balances,
+		Validators:                  bstate.Bellatrix.Validators,
+		EpochStructs:                duties,
+		Epoch:                       phase0.Epoch(bstate.Bellatrix.Slot / SlotsPerEpoch),
+		Slot:                        bstate.Bellatrix.Slot,
+		BlockRoots:                  bstate.Bellatrix.BlockRoots,
+		SyncCommittee:               *bstate.Bellatrix.CurrentSyncCommittee,
+		GenesisTimestamp:            bstate.Bellatrix.GenesisTime,
+		JustificationBits:           bstate.Bellatrix.JustificationBits,
+		PreviousJustifiedCheckpoint: *bstate.Bellatrix.PreviousJustifiedCheckpoint,
+		CurrentJustifiedCheckpoint:  *bstate.Bellatrix.CurrentJustifiedCheckpoint,
+		FinalizedCheckpoint:         *bstate.Bellatrix.FinalizedCheckpoint,
+		InactivityScores:            bstate.Bellatrix.InactivityScores,
+		Slashings:                   bstate.Bellatrix.Slashings,
+		LatestBlockHeader:           bstate.Bellatrix.LatestBlockHeader,
+		Eth1Data:                    bstate.Bellatrix.ETH1Data,
+		Eth1DepositIndex:            bstate.Bellatrix.ETH1DepositIndex,
 	}
 
 	bellatrixObj.Setup()
@@ -400,17 +467,24 @@ func NewBellatrixState(bstate spec.VersionedBeaconState, duties EpochDuties) Agn
 func NewCapellaState(bstate spec.VersionedBeaconState, duties EpochDuties) AgnosticState {
 
 	capellaObj := AgnosticState{
-		Version:                    bstate.Version,
-		Balances:                   bstate.Capella.Balances,
-		Validators:                 bstate.Capella.Validators,
-		EpochStructs:               duties,
-		Epoch:                      phase0.Epoch(bstate.Capella.Slot / SlotsPerEpoch),
-		Slot:                       bstate.Capella.Slot,
-		BlockRoots:                 bstate.Capella.BlockRoots,
-		SyncCommittee:              *bstate.Capella.CurrentSyncCommittee,
-		GenesisTimestamp:           bstate.Capella.GenesisTime,
-		CurrentJustifiedCheckpoint: *bstate.Capella.CurrentJustifiedCheckpoint,
-		LatestBlockHeader:          bstate.Capella.LatestBlockHeader,
+		Version:                     bstate.Version,
+		Balances:                    bstate.Capella.Balances,
+		Validators:                  bstate.Capella.Validators,
+		EpochStructs:                duties,
+		Epoch:                       phase0.Epoch(bstate.Capella.Slot / SlotsPerEpoch),
+		Slot:                        bstate.Capella.Slot,
+		BlockRoots:                  bstate.Capella.BlockRoots,
+		SyncCommittee:               *bstate.Capella.CurrentSyncCommittee,
+		GenesisTimestamp:            bstate.Capella.GenesisTime,
+		JustificationBits:           bstate.Capella.JustificationBits,
+		PreviousJustifiedCheckpoint: *bstate.Capella.PreviousJustifiedCheckpoint,
+		CurrentJustifiedCheckpoint:  *bstate.Capella.CurrentJustifiedCheckpoint,
+		FinalizedCheckpoint:         *bstate.Capella.FinalizedCheckpoint,
+		InactivityScores:            bstate.Capella.InactivityScores,
+		Slashings:                   bstate.Capella.Slashings,
+		LatestBlockHeader:           bstate.Capella.LatestBlockHeader,
+		Eth1Data:                    bstate.Capella.ETH1Data,
+		Eth1DepositIndex:            bstate.Capella.ETH1DepositIndex,
 	}
 
 	capellaObj.Setup()
@@ -424,17 +498,24 @@ func NewCapellaState(bstate spec.VersionedBeaconState, duties EpochDuties) Agnos
 func NewDenebState(bstate spec.VersionedBeaconState, duties EpochDuties) AgnosticState {
 
 	denebObj := AgnosticState{
-		Version:                    bstate.Version,
-		Balances:                   bstate.Deneb.Balances,
-		Validators:                 bstate.Deneb.Validators,
-		EpochStructs:               duties,
-		Epoch:                      phase0.Epoch(bstate.Deneb.Slot / SlotsPerEpoch),
-		Slot:                       bstate.Deneb.Slot,
-		BlockRoots:                 bstate.Deneb.BlockRoots,
-		SyncCommittee:              *bstate.Deneb.CurrentSyncCommittee,
-		GenesisTimestamp:           bstate.Deneb.GenesisTime,
-		CurrentJustifiedCheckpoint: *bstate.Deneb.CurrentJustifiedCheckpoint,
-		LatestBlockHeader:          bstate.Deneb.LatestBlockHeader,
+		Version:                     bstate.Version,
+		Balances:                    bstate.Deneb.Balances,
+		Validators:                  bstate.Deneb.Validators,
+		EpochStructs:                duties,
+		Epoch:                       phase0.Epoch(bstate.Deneb.Slot / SlotsPerEpoch),
+		Slot:                        bstate.Deneb.Slot,
+		BlockRoots:                  bstate.Deneb.BlockRoots,
+		SyncCommittee:               *bstate.Deneb.CurrentSyncCommittee,
+		GenesisTimestamp:            bstate.Deneb.GenesisTime,
+		JustificationBits:           bstate.Deneb.JustificationBits,
+		PreviousJustifiedCheckpoint: *bstate.Deneb.PreviousJustifiedCheckpoint,
+		CurrentJustifiedCheckpoint:  *bstate.Deneb.CurrentJustifiedCheckpoint,
+		FinalizedCheckpoint:         *bstate.Deneb.FinalizedCheckpoint,
+		InactivityScores:            bstate.Deneb.InactivityScores,
+		Slashings:                   bstate.Deneb.Slashings,
+		LatestBlockHeader:           bstate.Deneb.LatestBlockHeader,
+		Eth1Data:                    bstate.Deneb.ETH1Data,
+		Eth1DepositIndex:            bstate.Deneb.ETH1DepositIndex,
 	}
 
 	denebObj.Setup()
@@ -0,0 +1,91 @@
+package spec
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	benchValidators       = 500000 // similar to the mainnet validator set
+	benchDepositsPerBlock = 16     // MAX_DEPOSITS
+)
+
+func syntheticPubkey(i int) phase0.BLSPubKey {
+	var pubkey phase0.BLSPubKey
+	binary.BigEndian.PutUint64(pubkey[:], uint64(i)+1)
+	return pubkey
+}
+
+// State with numValidators validators and full blocks of top up deposits, spread over the validator set
+func syntheticDepositsState(numValidators int) *AgnosticState {
+	state := &AgnosticState{
+		Validators: make([]*phase0.Validator, numValidators),
+		Blocks:     make([]*AgnosticBlock, SlotsPerEpoch),
+	}
+	for i := range state.Validators {
+		state.Validators[i] = &phase0.Validator{PublicKey: syntheticPubkey(i)}
+	}
+
+	step := numValidators / (SlotsPerEpoch * benchDepositsPerBlock)
+	for slot := range state.Blocks {
+		block := &AgnosticBlock{Slot: phase0.Slot(slot)}
+		for i := 0; i < benchDepositsPerBlock; i++ {
+			valIdx := (slot*benchDepositsPerBlock + i) * step
+			block.Deposits = append(block.Deposits, &phase0.Deposit{
+				Data: &phase0.DepositData{
+					PublicKey: syntheticPubkey(valIdx),
+					Amount:    phase0.Gwei(valIdx + 1),
+				},
+			})
+		}
+		state.Blocks[slot] = block
+	}
+	return state
+}
+
+// Reference implementation: compare every deposit against every validator
+func calculateDepositsLinear(p *AgnosticState) {
+	p.Deposits = make([]phase0.Gwei, len(p.Validators))
+	for _, block := range p.Blocks {
+		for _, deposit := range block.Deposits {
+			for valIdx, validator := range p.Validators {
+				if deposit.Data.PublicKey == validator.PublicKey {
+					p.Deposits[valIdx] += deposit.Data.Amount
+				}
+			}
+		}
+	}
+}
+
+func TestCalculateDeposits(t *testing.T) {
+	state := syntheticDepositsState(10000)
+	calculateDepositsLinear(state)
+	expected := state.Deposits
+
+	state.pubkeyIndices = buildPubkeyIndices(state.Validators)
+	state.CalculateDeposits()
+
+	assert.Equal(t, expected, state.Deposits)
+	assert.Equal(t, phase0.Gwei(1), state.Deposits[0])
+}
+
+func BenchmarkCalculateDepositsLinear(b *testing.B) {
+	state := syntheticDepositsState(benchValidators)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		calculateDepositsLinear(state)
+	}
+}
+
+// includes building the index, which happens once per state in Setup
+func BenchmarkCalculateDeposits(b *testing.B) {
+	state := syntheticDepositsState(benchValidators)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		state.pubkeyIndices = buildPubkeyIndices(state.Validators)
+		state.CalculateDeposits()
+	}
+}
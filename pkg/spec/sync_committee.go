@@ -0,0 +1,122 @@
+package spec
+
+import (
+	"math/bits"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Sync committee participation of a single validator during an epoch
+// MissedSlots is a bitmap where bit i is set when the validator did not sign
+// in the i-th slot of the epoch. Slots without a proposed block are never marked as missed
+type ValidatorSyncParticipation struct {
+	ValIdx      phase0.ValidatorIndex
+	Epoch       phase0.Epoch
+	MissedSlots uint64
+	NumMissed   int
+}
+
+func (f ValidatorSyncParticipation) Type() ModelType {
+	return SyncCommitteeParticipationModel
+}
+
+// Resolves the sync aggregate bits of the given blocks against the sync committee pubkeys
+// A validator that appears several times in the committee misses a slot when any of its positions missed it
+func GetSyncCommitteeParticipation(
+	epoch phase0.Epoch,
+	syncCommittee altair.SyncCommittee,
+	pubkeyIndices map[phase0.BLSPubKey]phase0.ValidatorIndex,
+	blocks []*AgnosticBlock) []ValidatorSyncParticipation {
+
+	if len(syncCommittee.Pubkeys) == 0 {
+		return nil // no sync committee before altair
+	}
+
+	result := make([]ValidatorSyncParticipation, 0, len(syncCommittee.Pubkeys))
+	positions := make(map[phase0.ValidatorIndex]int) // position in the result for each validator
+	committeeIndices := make([]int, len(syncCommittee.Pubkeys))
+
+	for position, pubkey := range syncCommittee.Pubkeys {
+		valIdx, ok := pubkeyIndices[pubkey]
+		if !ok {
+			log.Warnf("sync committee member %#x not found in the validator list", pubkey)
+			committeeIndices[position] = -1
+			continue
+		}
+		resultIdx, ok := positions[valIdx]
+		if !ok {
+			resultIdx = len(result)
+			positions[valIdx] = resultIdx
+			result = append(result, ValidatorSyncParticipation{
+				ValIdx: valIdx,
+				Epoch:  epoch,
+			})
+		}
+		committeeIndices[position] = resultIdx
+	}
+
+	for _, block := range blocks {
+		if block == nil || !block.Proposed || block.SyncAggregate == nil {
+			continue
+		}
+		if phase0.Epoch(block.Slot/SlotsPerEpoch) != epoch {
+			continue
+		}
+		slotBit := uint64(1) << (block.Slot % SlotsPerEpoch)
+
+		for position, resultIdx := range committeeIndices {
+			if resultIdx < 0 || block.SyncAggregate.SyncCommitteeBits.BitAt(uint64(position)) {
+				continue
+			}
+			result[resultIdx].MissedSlots |= slotBit
+		}
+	}
+
+	for i := range result {
+		result[i].NumMissed = bits.OnesCount64(result[i].MissedSlots)
+	}
+
+	return result
+}
+
+// Member of the sync committee at a given position during a sync committee period
+type SyncCommitteeMember struct {
+	Period    uint64
+	Position  int
+	ValIdx    phase0.ValidatorIndex
+	PublicKey phase0.BLSPubKey
+}
+
+func (f SyncCommitteeMember) Type() ModelType {
+	return SyncCommitteeMemberModel
+}
+
+func GetSyncCommitteePeriod(epoch phase0.Epoch) uint64 {
+	return uint64(epoch) / EpochsPerSyncCommitteePeriod
+}
+
+// Returns one member per sync committee position, a validator can appear in several positions
+func GetSyncCommitteeMembers(
+	period uint64,
+	syncCommittee altair.SyncCommittee,
+	pubkeyIndices map[phase0.BLSPubKey]phase0.ValidatorIndex) []SyncCommitteeMember {
+
+	result := make([]SyncCommitteeMember, 0, len(syncCommittee.Pubkeys))
+
+	for position, pubkey := range syncCommittee.Pubkeys {
+		valIdx, ok := pubkeyIndices[pubkey]
+		if !ok {
+			log.Warnf("sync committee member %#x not found in the validator list", pubkey)
+			continue
+		}
+		result = append(result, SyncCommitteeMember{
+			Period:    period,
+			Position:  position,
+			ValIdx:    valIdx,
+			PublicKey: pubkey,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,97 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/assert"
+)
+
+// Block at the given slot whose sync aggregate is signed by every position but the missed ones
+func syncAggregateBlock(slot phase0.Slot, committeeSize int, missed ...int) *AgnosticBlock {
+	syncBits := bitfield.NewBitvector512()
+	for position := 0; position < committeeSize; position++ {
+		syncBits.SetBitAt(uint64(position), true)
+	}
+	for _, position := range missed {
+		syncBits.SetBitAt(uint64(position), false)
+	}
+	return &AgnosticBlock{
+		Slot:          slot,
+		Proposed:      true,
+		SyncAggregate: &altair.SyncAggregate{SyncCommitteeBits: syncBits},
+	}
+}
+
+func TestGetSyncCommitteeParticipation(t *testing.T) {
+	epoch := phase0.Epoch(2)
+	firstSlot := phase0.Slot(epoch) * SlotsPerEpoch
+
+	// validator 7 holds positions 0 and 2, the pubkey of position 3 is unknown
+	syncCommittee := altair.SyncCommittee{
+		Pubkeys: []phase0.BLSPubKey{syntheticPubkey(7), syntheticPubkey(8), syntheticPubkey(7), syntheticPubkey(100)},
+	}
+	pubkeyIndices := map[phase0.BLSPubKey]phase0.ValidatorIndex{
+		syntheticPubkey(7): 7,
+		syntheticPubkey(8): 8,
+	}
+
+	tests := []struct {
+		name     string
+		blocks   []*AgnosticBlock
+		expected []ValidatorSyncParticipation
+	}{
+		{
+			name:   "all signed",
+			blocks: []*AgnosticBlock{syncAggregateBlock(firstSlot, 4), syncAggregateBlock(firstSlot+1, 4)},
+			expected: []ValidatorSyncParticipation{
+				{ValIdx: 7, Epoch: epoch},
+				{ValIdx: 8, Epoch: epoch},
+			},
+		},
+		{
+			name:   "any position of a repeated validator misses the slot",
+			blocks: []*AgnosticBlock{syncAggregateBlock(firstSlot, 4), syncAggregateBlock(firstSlot+3, 4, 2)},
+			expected: []ValidatorSyncParticipation{
+				{ValIdx: 7, Epoch: epoch, MissedSlots: 1 << 3, NumMissed: 1},
+				{ValIdx: 8, Epoch: epoch},
+			},
+		},
+		{
+			name: "several missed slots",
+			blocks: []*AgnosticBlock{
+				syncAggregateBlock(firstSlot, 4, 1),
+				syncAggregateBlock(firstSlot+5, 4, 0, 1),
+				syncAggregateBlock(firstSlot+SlotsPerEpoch-1, 4, 1),
+			},
+			expected: []ValidatorSyncParticipation{
+				{ValIdx: 7, Epoch: epoch, MissedSlots: 1 << 5, NumMissed: 1},
+				{ValIdx: 8, Epoch: epoch, MissedSlots: 1 | 1<<5 | 1<<(SlotsPerEpoch-1), NumMissed: 3},
+			},
+		},
+		{
+			name: "missed blocks and blocks of other epochs are skipped",
+			blocks: []*AgnosticBlock{
+				{Slot: firstSlot + 1},
+				syncAggregateBlock(firstSlot-1, 4, 0, 1),
+				syncAggregateBlock(firstSlot+SlotsPerEpoch, 4, 0, 1),
+			},
+			expected: []ValidatorSyncParticipation{
+				{ValIdx: 7, Epoch: epoch},
+				{ValIdx: 8, Epoch: epoch},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := GetSyncCommitteeParticipation(epoch, syncCommittee, pubkeyIndices, test.blocks)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+
+	// no sync committee before altair
+	assert.Nil(t, GetSyncCommitteeParticipation(epoch, altair.SyncCommittee{}, pubkeyIndices, nil))
+}
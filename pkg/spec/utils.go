@@ -26,6 +26,35 @@ type BlockRewardsContent struct {
 	AttesterSlashings uint64 `json:"attester_slashings,string"`
 }
 
+type AttestationRewards struct {
+	ExecutionOptimistic bool                      `json:"execution_optimistic"`
+	Finalized           bool                      `json:"finalized"`
+	Data                AttestationRewardsContent `json:"data"`
+}
+
+type AttestationRewardsContent struct {
+	TotalRewards []ValidatorAttestationReward `json:"total_rewards"`
+}
+
+type ValidatorAttestationReward struct {
+	ValidatorIndex uint64 `json:"validator_index,string"`
+	Head           int64  `json:"head,string"`
+	Target         int64  `json:"target,string"`
+	Source         int64  `json:"source,string"`
+	Inactivity     int64  `json:"inactivity,string"`
+}
+
+type SyncCommitteeRewards struct {
+	ExecutionOptimistic bool                           `json:"execution_optimistic"`
+	Finalized           bool                           `json:"finalized"`
+	Data                []ValidatorSyncCommitteeReward `json:"data"`
+}
+
+type ValidatorSyncCommitteeReward struct {
+	ValidatorIndex uint64 `json:"validator_index,string"`
+	Reward         int64  `json:"reward,string"`
+}
+
 func FirstSlotInEpoch(slot phase0.Slot) phase0.Slot {
 	return slot / SlotsPerEpoch * SlotsPerEpoch
 }
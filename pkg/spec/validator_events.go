@@ -0,0 +1,72 @@
+package spec
+
+import (
+	"bytes"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Lifecycle event of a validator, detected between two consecutive states
+type ValidatorEvent struct {
+	ValIdx phase0.ValidatorIndex
+	Epoch  phase0.Epoch // epoch of the state where the event was first seen
+	Event  ValidatorEventType
+}
+
+func (f ValidatorEvent) Type() ModelType {
+	return ValidatorEventModel
+}
+
+// Compares the validator registry of two consecutive states and returns
+// the events that happened in the transition from prevState to nextState
+func GetValidatorEvents(prevState *AgnosticState, nextState *AgnosticState) []ValidatorEvent {
+
+	events := make([]ValidatorEvent, 0)
+	epoch := nextState.Epoch
+
+	addEvent := func(valIdx int, event ValidatorEventType) {
+		events = append(events, ValidatorEvent{
+			ValIdx: phase0.ValidatorIndex(valIdx),
+			Epoch:  epoch,
+			Event:  event,
+		})
+	}
+
+	for valIdx, validator := range nextState.Validators {
+
+		if valIdx >= len(prevState.Validators) {
+			// new validator in the registry: the rest of the fields keep their initial values
+			addEvent(valIdx, DEPOSITED_EVENT)
+			continue
+		}
+		prevValidator := prevState.Validators[valIdx]
+
+		if prevValidator.ActivationEligibilityEpoch == FarFutureEpoch && validator.ActivationEligibilityEpoch != FarFutureEpoch {
+			addEvent(valIdx, ELIGIBLE_EVENT)
+		}
+		if prevState.Epoch < validator.ActivationEpoch && validator.ActivationEpoch <= epoch {
+			addEvent(valIdx, ACTIVATED_EVENT)
+		}
+		if prevValidator.ExitEpoch == FarFutureEpoch && validator.ExitEpoch != FarFutureEpoch {
+			addEvent(valIdx, EXIT_INITIATED_EVENT)
+		}
+		if prevState.Epoch < validator.ExitEpoch && validator.ExitEpoch <= epoch {
+			addEvent(valIdx, EXITED_EVENT)
+		}
+		if !prevValidator.Slashed && validator.Slashed {
+			addEvent(valIdx, SLASHED_EVENT)
+		}
+		if prevState.Epoch < validator.WithdrawableEpoch && validator.WithdrawableEpoch <= epoch {
+			addEvent(valIdx, WITHDRAWABLE_EVENT)
+		}
+		if validator.WithdrawableEpoch <= epoch &&
+			prevState.Balances[valIdx] > 0 && nextState.Balances[valIdx] == 0 {
+			addEvent(valIdx, FULLY_WITHDRAWN_EVENT)
+		}
+		if !bytes.Equal(prevValidator.WithdrawalCredentials, validator.WithdrawalCredentials) {
+			addEvent(valIdx, CREDENTIALS_CHANGE_EVENT)
+		}
+	}
+
+	return events
+}
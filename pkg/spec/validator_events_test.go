@@ -0,0 +1,136 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+)
+
+// Active validator with BLS credentials and no exit
+func activeValidator() *phase0.Validator {
+	return &phase0.Validator{
+		WithdrawalCredentials:      []byte{0x00, 0x01},
+		EffectiveBalance:           32_000_000_000,
+		ActivationEligibilityEpoch: 1,
+		ActivationEpoch:            2,
+		ExitEpoch:                  FarFutureEpoch,
+		WithdrawableEpoch:          FarFutureEpoch,
+	}
+}
+
+// States of epochs 10 and 11 with a single validator
+func transitionStates(prev *phase0.Validator, next *phase0.Validator, prevBalance phase0.Gwei, nextBalance phase0.Gwei) (*AgnosticState, *AgnosticState) {
+	prevState := &AgnosticState{Epoch: 10}
+	if prev != nil {
+		prevState.Validators = []*phase0.Validator{prev}
+		prevState.Balances = []phase0.Gwei{prevBalance}
+	}
+	nextState := &AgnosticState{
+		Epoch:      11,
+		Validators: []*phase0.Validator{next},
+		Balances:   []phase0.Gwei{nextBalance},
+	}
+	return prevState, nextState
+}
+
+func TestGetValidatorEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		prev     func(*phase0.Validator)
+		next     func(*phase0.Validator)
+		new      bool
+		withdraw bool // balance goes to zero
+		expected []ValidatorEventType
+	}{
+		{
+			name: "no changes",
+		},
+		{
+			name:     "deposited",
+			new:      true,
+			expected: []ValidatorEventType{DEPOSITED_EVENT},
+		},
+		{
+			name: "eligible",
+			prev: func(v *phase0.Validator) {
+				v.ActivationEligibilityEpoch = FarFutureEpoch
+				v.ActivationEpoch = FarFutureEpoch
+			},
+			next:     func(v *phase0.Validator) { v.ActivationEligibilityEpoch = 11; v.ActivationEpoch = FarFutureEpoch },
+			expected: []ValidatorEventType{ELIGIBLE_EVENT},
+		},
+		{
+			name:     "activated",
+			prev:     func(v *phase0.Validator) { v.ActivationEpoch = 11 },
+			next:     func(v *phase0.Validator) { v.ActivationEpoch = 11 },
+			expected: []ValidatorEventType{ACTIVATED_EVENT},
+		},
+		{
+			name:     "exit initiated",
+			next:     func(v *phase0.Validator) { v.ExitEpoch = 20; v.WithdrawableEpoch = 40 },
+			expected: []ValidatorEventType{EXIT_INITIATED_EVENT},
+		},
+		{
+			name:     "exited",
+			prev:     func(v *phase0.Validator) { v.ExitEpoch = 11; v.WithdrawableEpoch = 40 },
+			next:     func(v *phase0.Validator) { v.ExitEpoch = 11; v.WithdrawableEpoch = 40 },
+			expected: []ValidatorEventType{EXITED_EVENT},
+		},
+		{
+			name:     "slashed",
+			next:     func(v *phase0.Validator) { v.Slashed = true; v.ExitEpoch = 20; v.WithdrawableEpoch = 40 },
+			expected: []ValidatorEventType{EXIT_INITIATED_EVENT, SLASHED_EVENT},
+		},
+		{
+			name:     "withdrawable and fully withdrawn",
+			prev:     func(v *phase0.Validator) { v.ExitEpoch = 5; v.WithdrawableEpoch = 11 },
+			next:     func(v *phase0.Validator) { v.ExitEpoch = 5; v.WithdrawableEpoch = 11 },
+			withdraw: true,
+			expected: []ValidatorEventType{WITHDRAWABLE_EVENT, FULLY_WITHDRAWN_EVENT},
+		},
+		{
+			name:     "balance to zero before withdrawable",
+			withdraw: true,
+		},
+		{
+			name:     "credentials change",
+			next:     func(v *phase0.Validator) { v.WithdrawalCredentials = []byte{0x01, 0x01} },
+			expected: []ValidatorEventType{CREDENTIALS_CHANGE_EVENT},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var prev *phase0.Validator
+			if !test.new {
+				prev = activeValidator()
+				if test.prev != nil {
+					test.prev(prev)
+				}
+			}
+			next := activeValidator()
+			if test.next != nil {
+				test.next(next)
+			}
+			nextBalance := phase0.Gwei(32_000_000_000)
+			if test.withdraw {
+				nextBalance = 0
+			}
+			prevState, nextState := transitionStates(prev, next, 32_000_000_000, nextBalance)
+
+			events := GetValidatorEvents(prevState, nextState)
+
+			eventTypes := make([]ValidatorEventType, 0)
+			for _, event := range events {
+				assert.Equal(t, phase0.ValidatorIndex(0), event.ValIdx)
+				assert.Equal(t, phase0.Epoch(11), event.Epoch)
+				eventTypes = append(eventTypes, event.Event)
+			}
+			if test.expected == nil {
+				test.expected = []ValidatorEventType{}
+			}
+			assert.Equal(t, test.expected, eventTypes)
+		})
+	}
+}
@@ -1,19 +1,23 @@
 package spec
 
 import (
+	"encoding/hex"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
 type ValidatorLastStatus struct {
-	ValIdx          phase0.ValidatorIndex
-	Epoch           phase0.Epoch
-	CurrentBalance  phase0.Gwei
-	CurrentStatus   ValidatorStatus
-	Slashed         bool
-	ActivationEpoch phase0.Epoch
-	WithdrawalEpoch phase0.Epoch
-	ExitEpoch       phase0.Epoch
-	PublicKey       phase0.BLSPubKey
+	ValIdx                phase0.ValidatorIndex
+	Epoch                 phase0.Epoch
+	CurrentBalance        phase0.Gwei
+	CurrentStatus         ValidatorStatus
+	Slashed               bool
+	ActivationEpoch       phase0.Epoch
+	WithdrawalEpoch       phase0.Epoch
+	ExitEpoch             phase0.Epoch
+	PublicKey             phase0.BLSPubKey
+	WithdrawalCredentials []byte
 }
 
 func (f ValidatorLastStatus) ToArray() []interface{} {
@@ -27,6 +31,7 @@ func (f ValidatorLastStatus) ToArray() []interface{} {
 	resultArgs = append(resultArgs, f.WithdrawalEpoch)
 	resultArgs = append(resultArgs, f.ExitEpoch)
 	resultArgs = append(resultArgs, f.PublicKey.String())
+	resultArgs = append(resultArgs, f.WithdrawalCredentialsToHex())
 	return resultArgs
 }
 
@@ -37,3 +42,26 @@ func (f ValidatorLastStatus) Type() ModelType {
 func (f ValidatorLastStatus) BalanceToEth() float32 {
 	return float32(f.CurrentBalance) / EffectiveBalanceInc
 }
+
+func (f ValidatorLastStatus) WithdrawalCredentialsToHex() string {
+	return "0x" + hex.EncodeToString(f.WithdrawalCredentials)
+}
+
+// 0x00 for BLS credentials, 0x01 and 0x02 for execution address credentials
+func (f ValidatorLastStatus) WithdrawalType() uint8 {
+	if len(f.WithdrawalCredentials) == 0 {
+		return BLSWithdrawalPrefix
+	}
+	return f.WithdrawalCredentials[0]
+}
+
+// Returns the execution address the validator withdraws to, false if the credentials are BLS
+func (f ValidatorLastStatus) WithdrawalAddress() (bellatrix.ExecutionAddress, bool) {
+	var address bellatrix.ExecutionAddress
+	if len(f.WithdrawalCredentials) != 32 || f.WithdrawalType() == BLSWithdrawalPrefix {
+		return address, false
+	}
+	// last 20 bytes of the credentials
+	copy(address[:], f.WithdrawalCredentials[12:])
+	return address, true
+}
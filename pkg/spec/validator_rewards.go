@@ -23,6 +23,38 @@ type ValidatorRewards struct {
 	MissingHead          bool
 	Status               ValidatorStatus
 	InclusionDelay       int
+	InactivityScore      uint64
+	RewardComponents
+}
+
+// Actual rewards (positive) and penalties (negative) obtained from the previous epoch to the given epoch
+// The sum of all the components is the balance delta, once withdrawals and deposits are discounted
+type RewardComponents struct {
+	SourceReward     int64
+	TargetReward     int64
+	HeadReward       int64
+	SyncReward       int64
+	ProposerReward   int64 // including attestations and sync aggregates
+	SlashingReward   int64 // whistleblower rewards minus the penalties of being slashed
+	InactivityReward int64 // inactivity leak penalty
+}
+
+func (f RewardComponents) Total() int64 {
+	return f.SourceReward + f.TargetReward + f.HeadReward + f.SyncReward +
+		f.ProposerReward + f.SlashingReward + f.InactivityReward
+}
+
+// Mismatch between a reward component computed by goteth and the one returned by the Beacon API
+type RewardsAudit struct {
+	ValIdx         phase0.ValidatorIndex
+	Epoch          phase0.Epoch
+	Component      string
+	ApiReward      int64
+	ComputedReward int64
+}
+
+func (f RewardsAudit) Type() ModelType {
+	return RewardsAuditModel
 }
 
 func (f ValidatorRewards) Type() ModelType {
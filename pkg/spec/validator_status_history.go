@@ -0,0 +1,80 @@
+package spec
+
+import (
+	"bytes"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Status of a validator from ValidFrom until the next change is recorded
+type ValidatorStatusHistory struct {
+	ValIdx                phase0.ValidatorIndex
+	ValidFrom             phase0.Epoch
+	Status                ValidatorStatus
+	EffectiveBalance      phase0.Gwei
+	Slashed               bool
+	WithdrawalCredentials []byte
+}
+
+func (f ValidatorStatusHistory) Type() ModelType {
+	return ValidatorStatusHistoryModel
+}
+
+func (f ValidatorStatusHistory) EffectiveBalanceToEth() float32 {
+	return float32(f.EffectiveBalance) / EffectiveBalanceInc
+}
+
+// Returns a new entry for every validator whose status, effective balance, slashed flag
+// or withdrawal credentials changed from prevState to nextState.
+// When prevState is empty, every validator in nextState is returned
+func GetValidatorStatusChanges(prevState *AgnosticState, nextState *AgnosticState) []ValidatorStatusHistory {
+
+	changes := make([]ValidatorStatusHistory, 0)
+
+	for valIdx, validator := range nextState.Validators {
+		idx := phase0.ValidatorIndex(valIdx)
+		status := nextState.GetValStatus(idx)
+
+		if !prevState.EmptyStateRoot() && valIdx < len(prevState.Validators) {
+			prevValidator := prevState.Validators[valIdx]
+			if prevState.GetValStatus(idx) == status &&
+				prevValidator.EffectiveBalance == validator.EffectiveBalance &&
+				prevValidator.Slashed == validator.Slashed &&
+				bytes.Equal(prevValidator.WithdrawalCredentials, validator.WithdrawalCredentials) {
+				continue // nothing changed
+			}
+		}
+
+		changes = append(changes, ValidatorStatusHistory{
+			ValIdx:                idx,
+			ValidFrom:             nextState.Epoch,
+			Status:                status,
+			EffectiveBalance:      validator.EffectiveBalance,
+			Slashed:               validator.Slashed,
+			WithdrawalCredentials: validator.WithdrawalCredentials,
+		})
+	}
+
+	return changes
+}
+
+// Whether both entries describe the same status, regardless of the epoch they are valid from
+func (f ValidatorStatusHistory) Equal(other ValidatorStatusHistory) bool {
+	return f.Status == other.Status &&
+		f.EffectiveBalance == other.EffectiveBalance &&
+		f.Slashed == other.Slashed &&
+		bytes.Equal(f.WithdrawalCredentials, other.WithdrawalCredentials)
+}
+
+// Removes the entries equal to the last stored status of their validator.
+// Used when there is no prevState to compare with, e.g. the first epoch processed after a restart
+func FilterStoredStatuses(changes []ValidatorStatusHistory, stored map[phase0.ValidatorIndex]ValidatorStatusHistory) []ValidatorStatusHistory {
+	filtered := make([]ValidatorStatusHistory, 0, len(changes))
+	for _, change := range changes {
+		if last, ok := stored[change.ValIdx]; ok && last.Equal(change) {
+			continue
+		}
+		filtered = append(filtered, change)
+	}
+	return filtered
+}
@@ -0,0 +1,97 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetValidatorStatusChanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		next      func(*phase0.Validator)
+		emptyPrev bool // first state processed
+		expected  []ValidatorStatusHistory
+	}{
+		{
+			name: "no changes",
+		},
+		{
+			name:      "first state returns every validator",
+			emptyPrev: true,
+			expected: []ValidatorStatusHistory{
+				{ValidFrom: 11, Status: ACTIVE_STATUS, EffectiveBalance: 32_000_000_000, WithdrawalCredentials: []byte{0x00, 0x01}},
+			},
+		},
+		{
+			name: "status change",
+			next: func(v *phase0.Validator) { v.ExitEpoch = 11 },
+			expected: []ValidatorStatusHistory{
+				{ValidFrom: 11, Status: EXIT_STATUS, EffectiveBalance: 32_000_000_000, WithdrawalCredentials: []byte{0x00, 0x01}},
+			},
+		},
+		{
+			name: "effective balance change",
+			next: func(v *phase0.Validator) { v.EffectiveBalance = 31_000_000_000 },
+			expected: []ValidatorStatusHistory{
+				{ValidFrom: 11, Status: ACTIVE_STATUS, EffectiveBalance: 31_000_000_000, WithdrawalCredentials: []byte{0x00, 0x01}},
+			},
+		},
+		{
+			name: "slashed",
+			next: func(v *phase0.Validator) { v.Slashed = true },
+			expected: []ValidatorStatusHistory{
+				{ValidFrom: 11, Status: SLASHED_STATUS, EffectiveBalance: 32_000_000_000, Slashed: true, WithdrawalCredentials: []byte{0x00, 0x01}},
+			},
+		},
+		{
+			name: "credentials change",
+			next: func(v *phase0.Validator) { v.WithdrawalCredentials = []byte{0x01, 0x01} },
+			expected: []ValidatorStatusHistory{
+				{ValidFrom: 11, Status: ACTIVE_STATUS, EffectiveBalance: 32_000_000_000, WithdrawalCredentials: []byte{0x01, 0x01}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := activeValidator()
+			if test.next != nil {
+				test.next(next)
+			}
+			prevState, nextState := transitionStates(activeValidator(), next, 32_000_000_000, 32_000_000_000)
+			if !test.emptyPrev {
+				prevState.StateRoot = &phase0.Root{0x01}
+			}
+
+			if test.expected == nil {
+				test.expected = []ValidatorStatusHistory{}
+			}
+			assert.Equal(t, test.expected, GetValidatorStatusChanges(prevState, nextState))
+		})
+	}
+
+	// validators that were not in the previous registry are new entries
+	prevState, nextState := transitionStates(nil, activeValidator(), 0, 32_000_000_000)
+	prevState.StateRoot = &phase0.Root{0x01}
+	assert.Len(t, GetValidatorStatusChanges(prevState, nextState), 1)
+}
+
+func TestFilterStoredStatuses(t *testing.T) {
+	_, nextState := transitionStates(activeValidator(), activeValidator(), 32_000_000_000, 32_000_000_000)
+	snapshot := GetValidatorStatusChanges(&AgnosticState{}, nextState) // first state after a restart
+
+	// nothing changed since the stored status, no new row
+	stored := map[phase0.ValidatorIndex]ValidatorStatusHistory{
+		0: {ValidFrom: 3, Status: ACTIVE_STATUS, EffectiveBalance: 32_000_000_000, WithdrawalCredentials: []byte{0x00, 0x01}},
+	}
+	assert.Empty(t, FilterStoredStatuses(snapshot, stored))
+
+	// the effective balance changed while goteth was stopped
+	stored[0] = ValidatorStatusHistory{ValidFrom: 3, Status: ACTIVE_STATUS, EffectiveBalance: 31_000_000_000, WithdrawalCredentials: []byte{0x00, 0x01}}
+	assert.Equal(t, snapshot, FilterStoredStatuses(snapshot, stored))
+
+	// validators without stored rows are kept
+	assert.Equal(t, snapshot, FilterStoredStatuses(snapshot, nil))
+}
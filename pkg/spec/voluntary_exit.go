@@ -0,0 +1,13 @@
+package spec
+
+import "github.com/attestantio/go-eth2-client/spec/phase0"
+
+type VoluntaryExit struct {
+	ValIdx phase0.ValidatorIndex
+	Epoch  phase0.Epoch // epoch from which the exit is valid
+	Slot   phase0.Slot  // slot of the block that included the exit
+}
+
+func (f VoluntaryExit) Type() ModelType {
+	return VoluntaryExitModel
+}
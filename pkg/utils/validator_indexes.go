@@ -26,7 +26,10 @@ func BoolToUint(input []bool) []uint64 {
 func DivideValidatorsBatches(input []phase0.ValidatorIndex, workers int) []PoolKeys {
 
 	result := make([]PoolKeys, 0)
-	step := len(input) / workers
+	if workers < 1 {
+		workers = 1
+	}
+	step := (len(input) + workers - 1) / workers // round up so there are at most as many batches as workers
 
 	includedIndex := 0
 	for includedIndex < len(input) {
@@ -23,7 +23,7 @@ var (
 
 type ValidatorWindowRunner struct {
 	ctx              context.Context
-	dbClient         *db.DBService  // client to communicate with psql
+	dbClient         db.Store       // storage where the rewards are pruned
 	eventsObj        events.Events  // object to receive signals from beacon node (needed to trigger the deletes)
 	stop             bool           // used to know if the tool should stop
 	windowEpochSize  int            // number of epochs of data to maintain in the database
@@ -49,6 +49,15 @@ func NewValidatorWindow(
 		}, errors.Wrap(err, "unable to connect DB Client.")
 	}
 
+	if iConfig.TTLEpochs > 0 {
+		err = idbClient.SetRetentionTTL(phase0.Epoch(iConfig.TTLEpochs))
+		if err != nil {
+			return &ValidatorWindowRunner{
+				ctx: pCtx,
+			}, errors.Wrap(err, "unable to set the TTL.")
+		}
+	}
+
 	// beacon node
 	cli, err := clientapi.NewAPIClient(pCtx,
 		iConfig.BnEndpoint)